@@ -0,0 +1,147 @@
+package box
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/mattn/go-runewidth"
+)
+
+// borderPart identifies a part of the border that can be colored on its own.
+type borderPart int
+
+const (
+	partInner  borderPart = iota // Glyphs inside the border, such as dividers.
+	partCorner                   // The four corners.
+	partTop                      // The top bar between the corners.
+	partBottom                   // The bottom bar between the corners.
+	partLeft                     // The left wall.
+	partRight                    // The right wall.
+)
+
+// borderPainter colors the border glyphs of a rows x cols box by their
+// position: each part takes its own color when one is set, and the
+// ColorGradient or Color otherwise.
+type borderPainter struct {
+	parts  [partRight + 1]color.Color // Converted part colors; nil means unset.
+	base   color.Color                // Converted Color.
+	grad   *gradient                  // Border gradient; overrides base when set.
+	rows   int
+	cols   int
+	leftW  int  // Width of the left wall.
+	rightW int  // Width of the right wall.
+	top    bool // Whether the first row is the top bar.
+	bottom bool // Whether the last row is the bottom bar.
+}
+
+// paintsByPosition reports whether border glyphs are colored by their
+// position in the box, as with a ColorGradient or part colors, rather than
+// all alike.
+func (b *Box) paintsByPosition() bool {
+	if len(b.gradient) > 0 {
+		return true
+	}
+	for _, c := range b.partColors() {
+		if c != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// partColors returns the colors of the border parts, indexed by borderPart.
+func (b *Box) partColors() [partRight + 1]string {
+	return [...]string{
+		partCorner: b.cornerColor,
+		partTop:    b.topColor,
+		partBottom: b.bottomColor,
+		partLeft:   b.leftColor,
+		partRight:  b.rightColor,
+	}
+}
+
+// borderPainter returns a painter for a border of rows x cols cells, or nil
+// when border glyphs are colored all alike.
+func (b *Box) borderPainter(rows, cols int, p colorprofile.Profile) (*borderPainter, error) {
+	if !b.paintsByPosition() {
+		return nil, nil
+	}
+	leftWall, rightWall := b.sideWalls()
+	bp := &borderPainter{
+		rows:   rows,
+		cols:   cols,
+		leftW:  wallWidth(leftWall),
+		rightW: wallWidth(rightWall),
+		top:    !b.hideTop,
+		bottom: !b.hideBottom,
+	}
+	var err error
+	for i, c := range b.partColors() {
+		if c == "" {
+			continue
+		}
+		if bp.parts[i], err = getConvertedColor(c, p); err != nil {
+			return nil, err
+		}
+	}
+	if b.color != "" {
+		if bp.base, err = getConvertedColor(b.color, p); err != nil {
+			return nil, err
+		}
+	}
+	if bp.grad, err = b.borderGradient(rows, cols, p); err != nil {
+		return nil, err
+	}
+	return bp, nil
+}
+
+// part returns the border part at row, col.
+func (bp *borderPainter) part(row, col int) borderPart {
+	onLeft, onRight := col < bp.leftW, col >= bp.cols-bp.rightW
+	bar := (bp.top && row == 0) || (bp.bottom && row == bp.rows-1)
+	switch {
+	case bar && (onLeft || onRight):
+		return partCorner
+	case bp.top && row == 0:
+		return partTop
+	case bp.bottom && row == bp.rows-1:
+		return partBottom
+	case onLeft:
+		return partLeft
+	case onRight:
+		return partRight
+	}
+	return partInner
+}
+
+// at returns the color of the glyph at row, col.
+func (bp *borderPainter) at(row, col int) color.Color {
+	if c := bp.parts[bp.part(row, col)]; c != nil {
+		return c
+	}
+	if bp.grad != nil {
+		return bp.grad.at(row, col)
+	}
+	return bp.base
+}
+
+// paint colors the unstyled glyphs of s, with s starting at column col of
+// the given row. Text carrying its own styling, such as colored labels, is
+// left as is.
+func (bp *borderPainter) paint(s string, row, col int) string {
+	return paintGraphemes(s, func(cluster string) color.Color {
+		c := col
+		col += runewidth.StringWidth(cluster)
+		if strings.TrimSpace(cluster) == "" {
+			return nil
+		}
+		return bp.at(row, c)
+	})
+}
+
+// labelSpace returns the space framing border labels on either side, with
+// the LabelSpaceBackground applied.
+func (b *Box) labelSpace(p colorprofile.Profile) (string, error) {
+	return applyBackground(" ", b.labelSpaceBg, p)
+}
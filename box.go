@@ -1,27 +1,35 @@
 package box
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/term"
-	"github.com/huandu/xstrings"
-	"github.com/mattn/go-runewidth"
 )
 
 const (
-	// 1 = separator, 2 = spacing, 3 = line; 4 = oddSpace; 5 = space; 6 = sideMargin
-	centerAlign = "%[1]s%[2]s%[3]s%[4]s%[2]s%[1]s"
-	leftAlign   = "%[1]s%[6]s%[3]s%[4]s%[2]s%[5]s%[1]s"
-	rightAlign  = "%[1]s%[2]s%[4]s%[5]s%[3]s%[6]s%[1]s"
+	// 1 = leftSeparator, 2 = leftMargin, 3 = line; 4 = oddSpace; 5 = space; 6 = rightMargin; 7 = rightSeparator
+	centerAlign = "%[1]s%[2]s%[5]s%[3]s%[4]s%[5]s%[6]s%[7]s"
+	leftAlign   = "%[1]s%[2]s%[3]s%[4]s%[5]s%[5]s%[6]s%[7]s"
+	rightAlign  = "%[1]s%[2]s%[5]s%[4]s%[5]s%[3]s%[6]s%[7]s"
 
 	defaultWrapDivisor = 3  // 2/3 of terminal width
 	minWrapWidth       = 20 // Minimum width to wrap content
 )
 
 // Box renders styled borders around text content.
+//
+// Rendering never modifies a Box, so once configured it can be rendered from
+// many goroutines at once, e.g. a shared base style used by worker
+// goroutines. Setters are not synchronized: configure a Box (or a Copy of
+// it) before sharing it.
 type Box struct {
 	// topRight renders the glyph used in the upper-right corner.
 	topRight string
@@ -35,32 +43,96 @@ type Box struct {
 	bottomLeft string
 	// horizontal renders the glyph used for the top and bottom edges.
 	horizontal string
+	// topTee renders the junction where an inner separator meets the top edge.
+	topTee string
+	// bottomTee renders the junction where an inner separator meets the bottom edge.
+	bottomTee string
+	// leftTee renders the junction where an inner separator meets the left wall.
+	leftTee string
+	// rightTee renders the junction where an inner separator meets the right wall.
+	rightTee string
+	// cross renders the junction where two inner separators cross.
+	cross string
+	// divider renders the glyph used for inner horizontal dividers.
+	divider string
+	// topEdge renders the top edge; empty means horizontal is used.
+	topEdge string
+	// bottomEdge renders the bottom edge; empty means horizontal is used.
+	bottomEdge string
+	// leftEdge renders the left wall; empty means vertical is used.
+	leftEdge string
+	// rightEdge renders the right wall; empty means vertical is used.
+	rightEdge string
 	config
 }
 
 // config contains configuration options for the Box.
 type config struct {
-	py            int           // Vertical padding.
-	px            int           // Horizontal padding.
-	contentAlign  AlignType     // Alignment for content inside the box.
-	style         BoxStyle      // Active box style preset.
-	titlePos      TitlePosition // Where the title, if any, is rendered.
-	titleColor    string        // ANSI color (or hex code) for the title.
-	contentColor  string        // ANSI color (or hex code) for the content.
-	color         string        // ANSI color (or hex code) for the box chrome.
-	allowWrapping bool          // Whether long content may wrap.
-	wrappingLimit int           // Custom wrap width when wrapping is enabled.
-	styleSet      bool          // Tracks if a style preset has already been applied.
+	padTop        int               // Padding above the content.
+	padRight      int               // Padding right of the content.
+	padBottom     int               // Padding below the content.
+	padLeft       int               // Padding left of the content.
+	marginTop     int               // Blank lines above the box.
+	marginRight   int               // Spaces right of the box.
+	marginBottom  int               // Blank lines below the box.
+	marginLeft    int               // Spaces left of the box, e.g. to indent it under a log prefix.
+	contentAlign  AlignType         // Alignment for content inside the box.
+	style         BoxStyle          // Active box style preset.
+	titlePos      TitlePosition     // Where the title, if any, is rendered.
+	titleAlign    AlignType         // Alignment of the title; empty means the position's default.
+	footer        string            // Secondary title rendered on the bottom border.
+	footerColor   string            // ANSI color (or hex code) for the footer.
+	footerAlign   AlignType         // Alignment of the footer on the bottom border.
+	labelColor    string            // ANSI color (or hex code) for border labels.
+	titleColor    string            // ANSI color (or hex code) for the title.
+	contentColor  string            // ANSI color (or hex code) for the content.
+	color         string            // ANSI color (or hex code) for the box chrome.
+	cornerColor   string            // Color of the corners; empty means color.
+	topColor      string            // Color of the top bar between the corners; empty means color.
+	bottomColor   string            // Color of the bottom bar between the corners; empty means color.
+	leftColor     string            // Color of the left wall; empty means color.
+	rightColor    string            // Color of the right wall; empty means color.
+	labelSpaceBg  string            // Background of the spaces framing border labels; empty means background.
+	background    string            // Background color of the whole box.
+	titleBg       string            // Background color of the title; empty means contentBg.
+	contentBg     string            // Background color of the inner area; empty means background.
+	titleAttrs    TextAttr          // Text attributes of the title, e.g. bold.
+	contentAttrs  TextAttr          // Text attributes of the content.
+	titleGrad     *TextGradient     // Title gradient; overrides titleColor when set.
+	contentGrad   *TextGradient     // Content gradient; overrides contentColor when set.
+	gradient      []string          // Border gradient stops; overrides color when set.
+	gradientDir   GradientDirection // Direction of the border gradient.
+	allowWrapping bool              // Whether long content may wrap.
+	wrappingLimit int               // Custom wrap width when wrapping is enabled.
+	minWidth      int               // Minimum total width including borders; 0 means unset.
+	maxWidth      int               // Maximum total width including borders; 0 means unset.
+	minHeight     int               // Minimum total height including borders; 0 means unset.
+	maxHeight     int               // Maximum total height including borders; 0 means unset.
+	overflow      OverflowType      // How content exceeding the maximum size is handled.
+	verticalAlign VerticalAlignType // Placement of content within a taller box.
+	ellipsis      string            // Marker appended to truncated content.
+	styleSet      bool              // Tracks if a style preset has already been applied.
+	hideTop       bool              // Whether the top bar is omitted.
+	hideRight     bool              // Whether the right wall is omitted.
+	hideBottom    bool              // Whether the bottom bar is omitted.
+	hideLeft      bool              // Whether the left wall is omitted.
+
+	topLabels    map[AlignType]string // Labels on the top border, keyed by slot.
+	bottomLabels map[AlignType]string // Labels on the bottom border, keyed by slot.
+
+	profile colorprofile.Profile // Explicit color profile; Unknown means detect from the output.
 }
 
 // NewBox creates a new Box with the box.Single style preset applied.
 func NewBox() *Box {
 	b := &Box{}
 	b.Style(Single)
+	b.ellipsis = "…"
 	return b
 }
 
-// Copy returns a shallow copy of the Box so further mutations do not affect the original.
+// Copy returns an independent copy of the Box, border labels included, so
+// further mutations do not affect the original.
 //
 // Useful for creating base styles and deriving multiple boxes from them.
 func (b *Box) Copy() *Box {
@@ -68,35 +140,82 @@ func (b *Box) Copy() *Box {
 		return nil
 	}
 	clone := *b
+	clone.topLabels = maps.Clone(b.topLabels)
+	clone.bottomLabels = maps.Clone(b.bottomLabels)
+	clone.gradient = slices.Clone(b.gradient)
 	return &clone
 }
 
 // Padding sets horizontal (px) and vertical (py) inner padding.
 func (b *Box) Padding(px, py int) *Box {
-	b.px = px
-	b.py = py
-	return b
+	return b.HPadding(px).VPadding(py)
 }
 
 // HPadding sets horizontal padding (left and right).
 func (b *Box) HPadding(px int) *Box {
-	b.px = px
+	b.padLeft = px
+	b.padRight = px
 	return b
 }
 
 // VPadding sets vertical padding (top and bottom).
 func (b *Box) VPadding(py int) *Box {
-	b.py = py
+	b.padTop = py
+	b.padBottom = py
+	return b
+}
+
+// PaddingTop sets the number of blank lines between the top border and the
+// content.
+func (b *Box) PaddingTop(n int) *Box {
+	b.padTop = n
+	return b
+}
+
+// PaddingRight sets the number of spaces between the content and the right
+// border.
+func (b *Box) PaddingRight(n int) *Box {
+	b.padRight = n
+	return b
+}
+
+// PaddingBottom sets the number of blank lines between the content and the
+// bottom border.
+func (b *Box) PaddingBottom(n int) *Box {
+	b.padBottom = n
+	return b
+}
+
+// PaddingLeft sets the number of spaces between the left border and the
+// content.
+func (b *Box) PaddingLeft(n int) *Box {
+	b.padLeft = n
+	return b
+}
+
+// Margin sets the whitespace emitted outside the border: blank lines above
+// and below the box, and spaces to its right and left. A left margin indents
+// every line of the box, e.g. under a log prefix.
+//
+// Margin lines are padded with spaces so the output stays rectangular.
+func (b *Box) Margin(top, right, bottom, left int) *Box {
+	b.marginTop = top
+	b.marginRight = right
+	b.marginBottom = bottom
+	b.marginLeft = left
 	return b
 }
 
 // Style selects one of the built-in BoxStyle presets.
 //
 // Common styles include box.Single, box.Double, box.Round, box.Bold,
-// box.SingleDouble, box.DoubleSingle, box.Classic, box.Hidden, and box.Block.
+// box.SingleDouble, box.DoubleSingle, box.Classic, box.Hidden, and box.Block;
+// Styles lists all of them, including those added with RegisterStyle.
 //
 // To make custom styles, call TopRight, TopLeft, BottomRight, BottomLeft,
-// Horizontal, and Vertical after Style to override individual glyphs.
+// Horizontal, and Vertical after Style to override individual glyphs. The
+// junction glyphs used by section dividers and tables can be overridden with
+// TopTee, BottomTee, LeftTee, RightTee, Cross, and Divider.
 //
 // Example:
 //
@@ -108,13 +227,23 @@ func (b *Box) Style(box BoxStyle) *Box {
 	// Set the box style characters from predefined styles
 	// This also allows manual overrides after setting style
 	// and have a standard base.
-	if styleDef, ok := boxes[box]; ok {
-		b.BottomLeft(styleDef.bottomLeft).
-			BottomRight(styleDef.bottomRight).
-			TopLeft(styleDef.topLeft).
-			TopRight(styleDef.topRight).
-			Horizontal(styleDef.horizontal).
-			Vertical(styleDef.vertical)
+	if styleDef, ok := LookupStyle(box); ok {
+		b.BottomLeft(styleDef.BottomLeft).
+			BottomRight(styleDef.BottomRight).
+			TopLeft(styleDef.TopLeft).
+			TopRight(styleDef.TopRight).
+			Horizontal(styleDef.Horizontal).
+			Vertical(styleDef.Vertical).
+			TopTee(styleDef.TopTee).
+			BottomTee(styleDef.BottomTee).
+			LeftTee(styleDef.LeftTee).
+			RightTee(styleDef.RightTee).
+			Cross(styleDef.Cross).
+			Divider(styleDef.Divider).
+			TopEdge(styleDef.TopEdge).
+			BottomEdge(styleDef.BottomEdge).
+			LeftEdge(styleDef.LeftEdge).
+			RightEdge(styleDef.RightEdge)
 	}
 	return b
 }
@@ -155,6 +284,76 @@ func (b *Box) Vertical(glyph string) *Box {
 	return b
 }
 
+// TopEdge sets the glyph used for the top edge, e.g. ▀ for half-block
+// styles. Empty means the Horizontal glyph is used instead.
+func (b *Box) TopEdge(glyph string) *Box {
+	b.topEdge = glyph
+	return b
+}
+
+// BottomEdge sets the glyph used for the bottom edge, e.g. ▄ for half-block
+// styles. Empty means the Horizontal glyph is used instead.
+func (b *Box) BottomEdge(glyph string) *Box {
+	b.bottomEdge = glyph
+	return b
+}
+
+// LeftEdge sets the glyph used for the left wall, e.g. ▌ for half-block
+// styles. Empty means the Vertical glyph is used instead.
+func (b *Box) LeftEdge(glyph string) *Box {
+	b.leftEdge = glyph
+	return b
+}
+
+// RightEdge sets the glyph used for the right wall, e.g. ▐ for half-block
+// styles. Empty means the Vertical glyph is used instead.
+func (b *Box) RightEdge(glyph string) *Box {
+	b.rightEdge = glyph
+	return b
+}
+
+// TopTee sets the junction glyph where an inner separator meets the top edge
+// (e.g. ┬). Empty means the horizontal glyph is used instead.
+func (b *Box) TopTee(glyph string) *Box {
+	b.topTee = glyph
+	return b
+}
+
+// BottomTee sets the junction glyph where an inner separator meets the bottom
+// edge (e.g. ┴). Empty means the horizontal glyph is used instead.
+func (b *Box) BottomTee(glyph string) *Box {
+	b.bottomTee = glyph
+	return b
+}
+
+// LeftTee sets the junction glyph where an inner separator meets the left wall
+// (e.g. ├). Empty means the vertical glyph is used instead.
+func (b *Box) LeftTee(glyph string) *Box {
+	b.leftTee = glyph
+	return b
+}
+
+// RightTee sets the junction glyph where an inner separator meets the right
+// wall (e.g. ┤). Empty means the vertical glyph is used instead.
+func (b *Box) RightTee(glyph string) *Box {
+	b.rightTee = glyph
+	return b
+}
+
+// Cross sets the junction glyph where two inner separators cross (e.g. ┼).
+// Empty means the horizontal glyph is used instead.
+func (b *Box) Cross(glyph string) *Box {
+	b.cross = glyph
+	return b
+}
+
+// Divider sets the glyph used for inner horizontal dividers between sections
+// and table rows. Empty means the horizontal glyph is used instead.
+func (b *Box) Divider(glyph string) *Box {
+	b.divider = glyph
+	return b
+}
+
 // TitleColor sets the color used for the title text.
 //
 // Accepts one of the first 16 ANSI color name constants (e.g. box.Green,
@@ -191,6 +390,164 @@ func (b *Box) Color(color string) *Box {
 	return b
 }
 
+// CornerColor sets the color of the four corners, overriding Color and
+// ColorGradient for them, e.g. for bright corners on a dim frame:
+//
+//	b.Color(box.BrightBlack).CornerColor(box.BrightWhite)
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) CornerColor(color string) *Box {
+	b.cornerColor = color
+	return b
+}
+
+// TopEdgeColor sets the color of the top bar between the corners,
+// overriding Color and ColorGradient for it. Labels on the bar without their
+// own color take it as well.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) TopEdgeColor(color string) *Box {
+	b.topColor = color
+	return b
+}
+
+// BottomEdgeColor sets the color of the bottom bar between the corners, like
+// TopEdgeColor does for the top bar.
+func (b *Box) BottomEdgeColor(color string) *Box {
+	b.bottomColor = color
+	return b
+}
+
+// LeftEdgeColor sets the color of the left wall, overriding Color and
+// ColorGradient for it. The tees where dividers meet the wall take it as
+// well.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) LeftEdgeColor(color string) *Box {
+	b.leftColor = color
+	return b
+}
+
+// RightEdgeColor sets the color of the right wall, like LeftEdgeColor does
+// for the left wall.
+func (b *Box) RightEdgeColor(color string) *Box {
+	b.rightColor = color
+	return b
+}
+
+// LabelSpaceBackground sets the background color of the spaces framing the
+// title, footer, and labels on the top and bottom bars. Set to the
+// TitleBackground, it extends the title's background over the spaces on
+// either side. It defaults to the Background.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) LabelSpaceBackground(color string) *Box {
+	b.labelSpaceBg = color
+	return b
+}
+
+// ColorGradient colors the border with a gradient fading between the given
+// stops, which accept the same values as Color, e.g.
+// ColorGradient(box.Cyan, "#ff00ff"). The stops are spaced evenly along the
+// GradientDirection, horizontal by default. Each glyph of the bars, walls
+// and dividers gets its own color, converted through the active color
+// profile like Color; labels with their own colors keep them.
+//
+// A gradient takes precedence over Color. Calling ColorGradient without
+// stops removes the gradient. Invalid colors cause Render to return an error.
+func (b *Box) ColorGradient(stops ...string) *Box {
+	b.gradient = slices.Clone(stops)
+	return b
+}
+
+// GradientDirection sets the direction of the ColorGradient.
+//
+// Valid directions are box.GradientHorizontal (the default),
+// box.GradientVertical, and box.GradientPerimeter.
+func (b *Box) GradientDirection(dir GradientDirection) *Box {
+	b.gradientDir = dir
+	return b
+}
+
+// Background sets the background color of the whole box, border and inner
+// area alike, so the box renders as a solid colored panel. Margins are left
+// uncolored.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) Background(color string) *Box {
+	b.background = color
+	return b
+}
+
+// TitleBackground sets the background color of the title. An Inside title
+// fills its lines across the inner area; a Top or Bottom title colors only
+// the title text on the border. It defaults to the ContentBackground.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) TitleBackground(color string) *Box {
+	b.titleBg = color
+	return b
+}
+
+// ContentBackground sets the background color of the inner area: content,
+// padding and alignment spaces. It defaults to the Background.
+//
+// Accepts the same values as Color. Invalid colors cause Render to return an
+// error.
+func (b *Box) ContentBackground(color string) *Box {
+	b.contentBg = color
+	return b
+}
+
+// TitleStyle sets text attributes for the title, such as
+// box.AttrBold|box.AttrUnderline. They combine with TitleColor and are kept
+// across ANSI resets embedded in the title. Profiles without styling support
+// (colorprofile.Ascii) render the title plain.
+func (b *Box) TitleStyle(attrs TextAttr) *Box {
+	b.titleAttrs = attrs
+	return b
+}
+
+// ContentStyle sets text attributes for the content, like TitleStyle does for
+// the title.
+func (b *Box) ContentStyle(attrs TextAttr) *Box {
+	b.contentAttrs = attrs
+	return b
+}
+
+// TitleGradient colors the title character by character, either fading
+// between the gradient's Stops or as a rainbow, e.g.
+// TitleGradient(box.TextGradient{Stops: []string{box.Yellow, "#ff00ff"}}).
+// Characters are grapheme clusters, so wide characters and emoji get a
+// single color, and text carrying its own ANSI styling keeps it. Colors are
+// converted through the active color profile like TitleColor.
+//
+// A gradient takes precedence over TitleColor. Invalid colors cause Render
+// to return an error.
+func (b *Box) TitleGradient(g TextGradient) *Box {
+	g.Stops = slices.Clone(g.Stops)
+	b.titleGrad = &g
+	return b
+}
+
+// ContentGradient colors the content character by character, like
+// TitleGradient does for the title. Each section is colored on its own and
+// so is each cell of a table.
+//
+// A gradient takes precedence over ContentColor. Invalid colors cause Render
+// to return an error.
+func (b *Box) ContentGradient(g TextGradient) *Box {
+	g.Stops = slices.Clone(g.Stops)
+	b.contentGrad = &g
+	return b
+}
+
 // TitlePosition sets where the title is rendered relative to the box.
 //
 // Valid positions are box.Inside, box.Top, and box.Bottom.
@@ -199,6 +556,143 @@ func (b *Box) TitlePosition(pos TitlePosition) *Box {
 	return b
 }
 
+// titlePosition returns the configured TitlePosition, defaulting to Inside.
+func (b *Box) titlePosition() TitlePosition {
+	if b.titlePos == "" {
+		return Inside
+	}
+	return b.titlePos
+}
+
+// innerBackground returns the background of the inner area, defaulting to
+// the box Background.
+func (b *Box) innerBackground() string {
+	if b.contentBg == "" {
+		return b.background
+	}
+	return b.contentBg
+}
+
+// titleBackground returns the background of Inside title lines, defaulting
+// to the inner background.
+func (b *Box) titleBackground() string {
+	if b.titleBg == "" {
+		return b.innerBackground()
+	}
+	return b.titleBg
+}
+
+// paintChrome colors border glyphs with the border color and background.
+// With a ColorGradient or part colors only the background is applied here;
+// the glyphs are colored once their position in the box is known.
+func (b *Box) paintChrome(s string, p colorprofile.Profile) (string, error) {
+	if !b.paintsByPosition() {
+		var err error
+		if s, err = applyColor(s, b.color, p); err != nil {
+			return "", err
+		}
+	}
+	return applyBackground(s, b.background, p)
+}
+
+// TitleAlign sets the horizontal alignment of the title.
+//
+// Supported values are box.Left, box.Center, and box.Right. On the Top and
+// Bottom borders the title is left-aligned by default, e.g. ┌ Title ─────┐;
+// box.Center gives ┌─── Title ───┐ and box.Right gives ┌───── Title ┐.
+// Inside titles are centered by default.
+func (b *Box) TitleAlign(align AlignType) *Box {
+	b.titleAlign = align
+	return b
+}
+
+// Footer sets a secondary title rendered on the bottom border, e.g. a status
+// such as "3 warnings", while the main title stays on top or inside.
+//
+// The box is widened to fit the footer when needed. The footer takes the
+// bottom slot matching its FooterAlign, so it can share the bottom border
+// with a title at box.Bottom as long as TitleAlign picks another slot. It
+// must be a single line.
+func (b *Box) Footer(footer string) *Box {
+	b.footer = footer
+	return b
+}
+
+// FooterColor sets the color used for the footer text.
+//
+// Accepts the same values as TitleColor. Invalid colors cause Render to
+// return an error.
+func (b *Box) FooterColor(color string) *Box {
+	b.footerColor = color
+	return b
+}
+
+// FooterAlign sets the horizontal alignment of the footer on the bottom
+// border. Supported values are box.Left (default), box.Center, and box.Right.
+func (b *Box) FooterAlign(align AlignType) *Box {
+	b.footerAlign = align
+	return b
+}
+
+// Borders sets which edges of the box are drawn. Hidden edges take no space
+// and the corners next to a hidden wall are dropped with it, so for example
+//
+//	b.Borders(false, false, false, true)
+//
+// draws only a left rule (blockquote style) and Borders(true, false, true,
+// false) draws horizontal rules above and below the content. Labels and a
+// title on a hidden top or bottom border cause Render to return an error.
+func (b *Box) Borders(top, right, bottom, left bool) *Box {
+	b.hideTop = !top
+	b.hideRight = !right
+	b.hideBottom = !bottom
+	b.hideLeft = !left
+	return b
+}
+
+// TopLabel sets the label shown in the given slot of the top border. Slots
+// are box.Left, box.Center and box.Right, so a border can carry up to three
+// labels, e.g. ┌ Files ──── 12/40 ─── [main] ┐. An empty label clears the
+// slot.
+//
+// A title at box.Top occupies the slot matching its TitleAlign and must not
+// share it with a label. The box is widened to fit all labels.
+func (b *Box) TopLabel(slot AlignType, label string) *Box {
+	b.topLabels = setLabel(b.topLabels, slot, label)
+	return b
+}
+
+// BottomLabel sets the label shown in the given slot of the bottom border.
+// It behaves like TopLabel; a title at box.Bottom or the footer occupies the
+// slot matching its alignment.
+func (b *Box) BottomLabel(slot AlignType, label string) *Box {
+	b.bottomLabels = setLabel(b.bottomLabels, slot, label)
+	return b
+}
+
+// LabelColor sets the color used for labels added with TopLabel and
+// BottomLabel.
+//
+// Accepts the same values as TitleColor. Invalid colors cause Render to
+// return an error.
+func (b *Box) LabelColor(color string) *Box {
+	b.labelColor = color
+	return b
+}
+
+// setLabel stores label under slot in labels, allocating the map as needed.
+func setLabel(labels map[AlignType]string, slot AlignType, label string) map[AlignType]string {
+	if label == "" {
+		delete(labels, slot)
+		return labels
+	}
+	if labels == nil {
+		labels = make(map[AlignType]string)
+	}
+	labels[slot] = label
+	return labels
+}
+
 // WrapContent enables or disables automatic wrapping of content.
 //
 // When enabled, content is wrapped to fit roughly two-thirds of the terminal
@@ -216,6 +710,87 @@ func (b *Box) WrapLimit(limit int) *Box {
 	return b
 }
 
+// Width fixes the total width of the box, borders included. It is shorthand
+// for MinWidth(n) followed by MaxWidth(n); 0 restores the content-derived
+// width.
+//
+// Shorter content is padded; wider content is handled according to Overflow.
+func (b *Box) Width(n int) *Box {
+	b.minWidth = n
+	b.maxWidth = n
+	return b
+}
+
+// MinWidth sets the minimum total width of the box, borders included.
+// Narrower boxes are padded to it; 0 means no minimum.
+func (b *Box) MinWidth(n int) *Box {
+	b.minWidth = n
+	return b
+}
+
+// MaxWidth sets the maximum total width of the box, borders included. Wider
+// content is handled according to Overflow; 0 means no maximum.
+//
+// Border labels, including a title on the border, are never cut: Render
+// returns an error when they do not fit.
+func (b *Box) MaxWidth(n int) *Box {
+	b.maxWidth = n
+	return b
+}
+
+// Height fixes the total height of the box, borders included. It is
+// shorthand for MinHeight(n) followed by MaxHeight(n); 0 restores the
+// content-derived height.
+func (b *Box) Height(n int) *Box {
+	b.minHeight = n
+	b.maxHeight = n
+	return b
+}
+
+// MinHeight sets the minimum total height of the box, borders included.
+// Shorter boxes get blank lines below the content; 0 means no minimum.
+func (b *Box) MinHeight(n int) *Box {
+	b.minHeight = n
+	return b
+}
+
+// MaxHeight sets the maximum total height of the box, borders included.
+// Taller content is handled according to Overflow; 0 means no maximum.
+func (b *Box) MaxHeight(n int) *Box {
+	b.maxHeight = n
+	return b
+}
+
+// VerticalAlign sets where the content sits when the box is taller than it,
+// for example because of Height or MinHeight.
+//
+// Supported values are box.AlignTop (default), box.AlignMiddle and
+// box.AlignBottom. The extra blank lines are added inside the vertical
+// padding.
+func (b *Box) VerticalAlign(align VerticalAlignType) *Box {
+	b.verticalAlign = align
+	return b
+}
+
+// Overflow sets how content that exceeds MaxWidth or MaxHeight is handled.
+//
+// Supported values are box.OverflowWrap (default), box.OverflowTruncate and
+// box.OverflowError.
+func (b *Box) Overflow(policy OverflowType) *Box {
+	b.overflow = policy
+	return b
+}
+
+// Ellipsis sets the marker appended to truncated content. It defaults to
+// "…"; an empty string cuts content without a marker.
+//
+// Truncation is ANSI-aware: escape sequences and wide characters are never
+// cut in half.
+func (b *Box) Ellipsis(ellipsis string) *Box {
+	b.ellipsis = ellipsis
+	return b
+}
+
 // ContentAlign sets the horizontal alignment of content inside the box.
 //
 // Supported values are box.Left, box.Center, and box.Right.
@@ -224,6 +799,17 @@ func (b *Box) ContentAlign(align AlignType) *Box {
 	return b
 }
 
+// ColorProfile sets an explicit color profile used to degrade colors.
+//
+// By default the profile is detected from the output the box is rendered
+// for (os.Stdout for Render, the destination writer for RenderTo). Passing
+// colorprofile.Unknown restores detection. With colorprofile.Ascii or
+// colorprofile.NoTTY no color sequences are emitted at all.
+func (b *Box) ColorProfile(p colorprofile.Profile) *Box {
+	b.profile = p
+	return b
+}
+
 // MustRender is like Render but panics if an error occurs.
 //
 // Use MustRender in examples or CLIs where failures should abort execution
@@ -238,6 +824,9 @@ func (b *Box) MustRender(title, content string) string {
 
 // Render generates the box with the given title and content.
 //
+// Colors and the default wrap width are derived from os.Stdout. Use RenderTo
+// to render for a different destination.
+//
 // It returns an error if:
 //   - the BoxStyle is invalid,
 //   - the TitlePosition is invalid,
@@ -245,169 +834,678 @@ func (b *Box) MustRender(title, content string) string {
 //   - padding is negative,
 //   - a multiline title is used with a non-Inside TitlePosition, or
 //   - any configured colors are invalid.
+//
+// Configuration problems are reported together, as *ConfigError values
+// joined with errors.Join, and wrap sentinel errors such as ErrInvalidStyle
+// for use with errors.Is and errors.As.
 func (b *Box) Render(title, content string) (string, error) {
+	return b.render(os.Stdout, title, []string{content})
+}
+
+// RenderSections generates a box whose content is split into sections (for
+// example header, body and footer) separated by full-width dividers that
+// join the side walls, such as ├────┤ for box.Single or ╠════╣ for
+// box.Double.
+//
+// Each section is wrapped, padded vertically and aligned like the content of
+// Render. It returns the same errors as Render.
+func (b *Box) RenderSections(title string, sections ...string) (string, error) {
+	return b.render(os.Stdout, title, sections)
+}
+
+// RenderTo renders the box and writes it to w.
+//
+// Unlike Render, terminal detection, the default wrap width and the color
+// profile are derived from w itself, so boxes written to os.Stderr, files or
+// network connections are formatted for that destination: writers that are
+// not terminals get no color sequences unless the environment forces them
+// (e.g. CLICOLOR_FORCE). An explicit profile set with ColorProfile takes
+// precedence over detection.
+//
+// It returns the same configuration errors as Render, or the error reported
+// by w.
+func (b *Box) RenderTo(w io.Writer, title, content string) error {
+	s, err := b.render(w, title, []string{content})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// RenderSectionsTo renders a box split into sections, like RenderSections,
+// and writes it to w. Like RenderTo, the default wrap width and the color
+// profile are derived from w itself.
+//
+// It returns the same errors as RenderSections, or the error reported by w.
+func (b *Box) RenderSectionsTo(w io.Writer, title string, sections ...string) error {
+	s, err := b.render(w, title, sections)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// Validate reports the configuration problems Render would report, without
+// rendering anything, so boxes built from user configuration can fail fast
+// at startup. It needs neither a title, content nor a terminal. Border labels
+// and the footer are checked too: labels sharing a slot, labels on a hidden
+// border and labels wider than MaxWidth allows are all reported.
+//
+// Problems that depend on the rendered text, such as content exceeding a
+// maximum size, a multiline title at a non-Inside TitlePosition or a Top or
+// Bottom title sharing a slot with a label, are only reported by Render. The error is built like Render's: *ConfigError values
+// joined with errors.Join.
+func (b *Box) Validate() error {
+	return b.validate("")
+}
+
+// validate checks the configuration of b for rendering with title,
+// reporting every problem found as a *ConfigError joined with errors.Join.
+func (b *Box) validate(title string) error {
+	var errs []error
 	if b.styleSet {
-		if _, ok := boxes[b.config.style]; !ok {
-			return "", fmt.Errorf("invalid Box style %s", b.config.style)
+		if _, ok := LookupStyle(b.config.style); !ok {
+			errs = append(errs, configError("Style", b.config.style, ErrInvalidStyle, "invalid Box style %s", b.config.style))
+		}
+	}
+
+	if _, ok := alignFormat(b.titleAlign); !ok {
+		errs = append(errs, configError("TitleAlign", b.titleAlign, ErrInvalidAlignment, "invalid Title Alignment %s", b.titleAlign))
+	}
+	if _, ok := alignFormat(b.footerAlign); !ok {
+		errs = append(errs, configError("FooterAlign", b.footerAlign, ErrInvalidAlignment, "invalid Footer Alignment %s", b.footerAlign))
+	}
+	if _, err := b.findAlign(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, _, err := vertOffsets(0, 0, b.verticalAlign); err != nil {
+		errs = append(errs, configError("VerticalAlign", b.verticalAlign, ErrInvalidAlignment, "%s", err))
+	}
+
+	titlePos := b.titlePosition()
+	switch titlePos {
+	case Inside, Top, Bottom:
+	default:
+		errs = append(errs, configError("TitlePosition", b.titlePos, ErrInvalidTitlePosition, "invalid TitlePosition %s", b.titlePos))
+	}
+	if title != "" && titlePos != Inside && strings.Contains(title, "\n") {
+		errs = append(errs, configError("TitlePosition", b.titlePos, ErrMultiline, "multiline titles are only supported Inside title position only"))
+	}
+	if strings.Contains(b.footer, "\n") {
+		errs = append(errs, configError("Footer", b.footer, ErrMultiline, "multiline footers are not supported"))
+	}
+	for _, edge := range []struct {
+		field  string
+		labels map[AlignType]string
+	}{{"TopLabel", b.topLabels}, {"BottomLabel", b.bottomLabels}} {
+		for slot, label := range edge.labels {
+			if _, ok := slotIndex(slot); !ok || slot == "" {
+				errs = append(errs, configError(edge.field, slot, ErrInvalidAlignment, "invalid Label slot %s", slot))
+			}
+			if strings.Contains(label, "\n") {
+				errs = append(errs, configError(edge.field, label, ErrMultiline, "multiline labels are not supported"))
+			}
+		}
+	}
+
+	if err := b.validateSpacing(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := b.validateSize(); err != nil {
+		errs = append(errs, err)
+	} else if b.maxWidth > 0 {
+		leftWall, rightWall := b.sideWalls()
+		if b.maxWidth-wallWidth(leftWall)-wallWidth(rightWall)-b.padLeft-b.padRight < 1 {
+			errs = append(errs, configError("MaxWidth", b.maxWidth, ErrInvalidSize, "width %d leaves no room for content", b.maxWidth))
 		}
 	}
+	// Labels that Render cannot place on the border: slot conflicts, labels
+	// on hidden borders and labels wider than MaxWidth allows.
+	top, bottom, labelErrs := b.placeLabels(slotLabels(b.topLabels), slotLabels(b.bottomLabels), title, b.footer, titlePos)
+	errs = append(errs, labelErrs...)
+	if b.maxWidth > 0 {
+		leftWall, rightWall := b.sideWalls()
+		for _, bar := range []struct {
+			labels      [3]string
+			left, right string
+		}{{top, b.topLeft, b.topRight}, {bottom, b.bottomLeft, b.bottomRight}} {
+			inner := b.maxWidth - wallWidth(glyphIf(bar.left, leftWall != "")) - wallWidth(glyphIf(bar.right, rightWall != ""))
+			if need := labelsMinWidth(bar.labels); need > inner {
+				errs = append(errs, configError("MaxWidth", b.maxWidth, ErrContentOverflow, "border labels need %d cells but only %d are available", need, inner))
+			}
+		}
+	}
+	if b.allowWrapping && b.wrappingLimit < 0 {
+		errs = append(errs, configError("WrapLimit", b.wrappingLimit, ErrNegativeValue, "wrapping limit cannot be negative"))
+	}
+
+	for _, c := range []struct{ field, color string }{
+		{"Color", b.color},
+		{"CornerColor", b.cornerColor},
+		{"TopEdgeColor", b.topColor},
+		{"BottomEdgeColor", b.bottomColor},
+		{"LeftEdgeColor", b.leftColor},
+		{"RightEdgeColor", b.rightColor},
+		{"LabelSpaceBackground", b.labelSpaceBg},
+		{"TitleColor", b.titleColor},
+		{"ContentColor", b.contentColor},
+		{"FooterColor", b.footerColor},
+		{"LabelColor", b.labelColor},
+		{"Background", b.background},
+		{"TitleBackground", b.titleBg},
+		{"ContentBackground", b.contentBg},
+	} {
+		if err := validateColor(c.field, c.color); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, g := range []struct {
+		field string
+		stops []string
+	}{
+		{"ColorGradient", b.gradient},
+		{"TitleGradient", b.titleGrad.stops()},
+		{"ContentGradient", b.contentGrad.stops()},
+	} {
+		for _, stop := range g.stops {
+			// Unlike an unset color, an empty stop has no color to blend.
+			if stop == "" {
+				errs = append(errs, configError(g.field, stop, ErrInvalidColor, "gradient stops cannot be empty"))
+			} else if err := validateColor(g.field, stop); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	switch b.gradientDir {
+	case "", GradientHorizontal, GradientVertical, GradientPerimeter:
+	default:
+		errs = append(errs, configError("GradientDirection", b.gradientDir, ErrInvalidGradient, "invalid GradientDirection %s", b.gradientDir))
+	}
+	return errors.Join(errs...)
+}
 
+// render generates the box with the given title and content sections,
+// formatted for the output w.
+func (b *Box) render(w io.Writer, title string, sections []string) (string, error) {
+	if err := b.validate(title); err != nil {
+		return "", err
+	}
+
+	p := b.outputProfile(w)
 	var content_ []string
 
+	// Visible widths of the side walls; hidden walls take no space.
+	leftWall, rightWall := b.sideWalls()
+	wallsWidth := wallWidth(leftWall) + wallWidth(rightWall)
+	barRows := b.barRows()
+
+	// Widest content line allowed by MaxWidth, 0 when unbounded.
+	maxContentWidth := 0
+	if b.maxWidth > 0 {
+		maxContentWidth = b.maxWidth - wallsWidth - b.padLeft - b.padRight
+	}
+
 	// Allow wrapping according to the user
+	wrapWidth := 0
 	if b.allowWrapping {
-		if b.wrappingLimit < 0 {
-			return "", fmt.Errorf("wrapping limit cannot be negative")
-		}
 		// If limit not provided then use 2*TermWidth/3 as limit else
 		// use the one provided
 		if b.wrappingLimit != 0 {
-			content = ansi.Wrap(content, b.wrappingLimit, "")
-		} else {
-			if !isTTY(os.Stdout.Fd()) {
-				return "", fmt.Errorf("cannot determine terminal width; use WrapLimit to set an explicit wrap limit when wrapping on non-TTY outputs")
-			}
-			width, _, err := term.GetSize(os.Stdout.Fd())
+			wrapWidth = b.wrappingLimit
+		} else if maxContentWidth == 0 {
+			width, err := termWidth(w)
 			if err != nil {
-				return "", fmt.Errorf("cannot determine terminal width: %v", err)
+				return "", err
 			}
 			// Use 2/3 of terminal width as default wrapping limit
-			wrapWidth := max(2*width/defaultWrapDivisor, minWrapWidth)
-			content = ansi.Wrap(content, wrapWidth, "")
+			wrapWidth = max(2*width/defaultWrapDivisor, minWrapWidth)
 		}
 	}
+	// Content wider than MaxWidth is wrapped unless another overflow policy is set.
+	if maxContentWidth > 0 && (b.overflow == "" || b.overflow == OverflowWrap) {
+		if wrapWidth == 0 || wrapWidth > maxContentWidth {
+			wrapWidth = maxContentWidth
+		}
+	}
+	// Styling below replaces the sections, so work on a copy and leave the
+	// caller's slice untouched.
+	sections = slices.Clone(sections)
+	if len(sections) == 0 {
+		sections = []string{""}
+	}
 
-	title, err := applyColor(title, b.titleColor)
+	title, err := applyTextColor(title, b.titleColor, b.titleGrad, 0, p)
 	if err != nil {
 		return "", err
 	}
-	content, err = applyColor(content, b.contentColor)
+	title = applyAttrs(title, b.titleAttrs, p)
+	footer, err := applyColor(b.footer, b.footerColor, p)
 	if err != nil {
 		return "", err
 	}
-
-	if b.titlePos == "" {
-		b.titlePos = Inside
+	// line counts the content lines so far, continuing a rainbow across
+	// sections.
+	line := 0
+	for i, section := range sections {
+		// Rigid blocks (nested boxes) are kept intact and only text is wrapped.
+		section = wrapContent(section, wrapWidth)
+		if section, err = applyTextColor(section, b.contentColor, b.contentGrad, line, p); err != nil {
+			return "", err
+		}
+		line += strings.Count(section, "\n") + 1
+		sections[i] = applyAttrs(section, b.contentAttrs, p)
 	}
 
-	if title != "" {
-		if b.titlePos != Inside && strings.Contains(title, "\n") {
-			return "", fmt.Errorf("multiline titles are only supported Inside title position only")
+	titlePos := b.titlePosition()
+	// A title on the border only gets its own background; Inside titles fill
+	// their lines in formatLine.
+	if titlePos != Inside {
+		if title, err = applyBackground(title, b.titleBg, p); err != nil {
+			return "", err
 		}
-		if b.titlePos == Inside {
-			content_ = append(content_, strings.Split(title, "\n")...)
-			content_ = append(content_, []string{""}...) // for empty line between title and content
+	}
+	if title != "" && titlePos == Inside {
+		content_ = append(content_, strings.Split(title, "\n")...)
+		content_ = append(content_, []string{""}...) // for empty line between title and content
+	}
+	// dividers holds the indexes of content lines that start a new section.
+	var dividers []int
+	for i, section := range sections {
+		if i > 0 {
+			dividers = append(dividers, len(content_))
 		}
+		content_ = append(content_, strings.Split(section, "\n")...)
 	}
-	content_ = append(content_, strings.Split(content, "\n")...)
 
 	titleLen := 0
 	if title != "" {
 		titleLen = len(strings.Split(ansi.Strip(title), "\n"))
 	}
 
-	if b.px < 0 {
-		return "", fmt.Errorf("horizontal padding cannot be negative")
-	}
-	if b.py < 0 {
-		return "", fmt.Errorf("vertical padding cannot be negative")
+	// Drop the rows that do not fit MaxHeight.
+	if b.maxHeight > 0 {
+		padRows := b.padTop + b.padBottom
+		rows := b.maxHeight - barRows - padRows
+		if rows < 1 {
+			return "", configError("MaxHeight", b.maxHeight, ErrInvalidSize, "height %d leaves no room for content", b.maxHeight)
+		}
+		total := barRows + padRows + len(content_) + len(dividers)*(padRows+1)
+		var cut bool
+		content_, dividers, cut = fitHeight(content_, dividers, rows, padRows+1)
+		if cut {
+			if b.overflow == OverflowError {
+				return "", configError("MaxHeight", b.maxHeight, ErrContentOverflow, "content height %d exceeds the maximum of %d", total, b.maxHeight)
+			}
+			content_[len(content_)-1] += b.ellipsis
+		}
 	}
 
-	sideMargin := strings.Repeat(" ", b.px)
+	leftMargin, rightMargin := strings.Repeat(" ", b.padLeft), strings.Repeat(" ", b.padRight)
 	_longestLine, lines2 := longestLine(content_)
 
+	// Cut the lines that are still wider than MaxWidth, such as nested boxes
+	// and Inside titles which are never wrapped.
+	if maxContentWidth > 0 && _longestLine > maxContentWidth {
+		if b.overflow == OverflowError {
+			return "", configError("MaxWidth", b.maxWidth, ErrContentOverflow, "content width %d exceeds the maximum of %d", _longestLine+wallsWidth+b.padLeft+b.padRight, b.maxWidth)
+		}
+		lines2 = truncateLines(lines2, maxContentWidth, b.ellipsis)
+		_longestLine = 0
+		for _, l := range lines2 {
+			_longestLine = max(_longestLine, l.len)
+		}
+	}
+
 	// Compute desired inner width (between the vertical borders, excluding them).
-	contentInnerWidth := _longestLine + 2*b.px
+	contentInnerWidth := _longestLine + b.padLeft + b.padRight
 	innerWidth := contentInnerWidth
 
-	// Make sure the box is wide enough to fit the title when it's on Top/Bottom.
-	if b.titlePos != Inside && title != "" {
-		titleWidth := runewidth.StringWidth(ansi.Strip(title))
-		minTitleInnerWidth := titleWidth + 2 // title + left/right padding
-
-		if minTitleInnerWidth > innerWidth {
-			innerWidth = minTitleInnerWidth
-		}
+	// Make sure the box is wide enough to fit the border labels, including a
+	// Top/Bottom title and the footer.
+	topLabels, bottomLabels, err := b.borderLabels(title, footer, titlePos, p)
+	if err != nil {
+		return "", err
+	}
+	innerWidth = max(innerWidth, labelsMinWidth(topLabels), labelsMinWidth(bottomLabels), b.minWidth-wallsWidth)
+	// Labels never widen the box past MaxWidth; buildTitledBar reports
+	// labels that do not fit.
+	if b.maxWidth > 0 {
+		innerWidth = min(innerWidth, b.maxWidth-wallsWidth)
 	}
 
-	// If we enlarged the inner width to fit the title, reflect that in longestLine.
+	// If we enlarged the inner width to fit the labels or MinWidth, reflect that in longestLine.
 	if innerWidth > contentInnerWidth {
-		_longestLine = max(innerWidth-2*b.px, 0)
+		_longestLine = max(innerWidth-b.padLeft-b.padRight, 0)
 	}
 
 	// Visible widths of box characters; fall back to 1 so we always make progress.
-	verticalWidth := charWidth(b.vertical)
-	horizontalWidth := charWidth(b.horizontal)
-	topLeftWidth := charWidth(b.topLeft)
-	topRightWidth := charWidth(b.topRight)
-	bottomLeftWidth := charWidth(b.bottomLeft)
-	bottomRightWidth := charWidth(b.bottomRight)
+	topEdge, bottomEdge := glyphOr(b.topEdge, b.horizontal), glyphOr(b.bottomEdge, b.horizontal)
+	topEdgeWidth, bottomEdgeWidth := charWidth(topEdge), charWidth(bottomEdge)
+	// Corners next to a hidden wall are dropped along with it.
+	topLeft, topRight := glyphIf(b.topLeft, leftWall != ""), glyphIf(b.topRight, rightWall != "")
+	bottomLeft, bottomRight := glyphIf(b.bottomLeft, leftWall != ""), glyphIf(b.bottomRight, rightWall != "")
 
 	// Ensure the inner width is a multiple of the horizontal glyph width when
 	// drawing horizontal bars (e.g. emoji) so we don't need to pad with extra
 	// spaces before the corner. This keeps the bar visually uniform.
-	if horizontalWidth > 1 && innerWidth%horizontalWidth != 0 {
-		innerWidth += horizontalWidth - (innerWidth % horizontalWidth)
-		_longestLine = max(innerWidth-2*b.px, 0)
+	// A fixed width takes precedence; the bars are then padded with spaces.
+	if (innerWidth%topEdgeWidth != 0 || innerWidth%bottomEdgeWidth != 0) && b.maxWidth == 0 {
+		for innerWidth%topEdgeWidth != 0 || innerWidth%bottomEdgeWidth != 0 {
+			innerWidth++
+		}
+		_longestLine = max(innerWidth-b.padLeft-b.padRight, 0)
 	}
 
 	// Total visible width of a rendered line (including vertical borders).
-	lineWidth := innerWidth + 2*verticalWidth
+	lineWidth := innerWidth + wallsWidth
 
-	TopBar := buildPlainBar(b.topLeft, b.horizontal, b.topRight, topLeftWidth, topRightWidth, lineWidth, horizontalWidth)
-	BottomBar := buildPlainBar(b.bottomLeft, b.horizontal, b.bottomRight, bottomLeftWidth, bottomRightWidth, lineWidth, horizontalWidth)
+	TopBar, err := buildTitledBar(topLeft, topEdge, topRight, wallWidth(topLeft), wallWidth(topRight), lineWidth, topEdgeWidth, topLabels)
+	if err != nil {
+		return "", err
+	}
+	BottomBar, err := buildTitledBar(bottomLeft, bottomEdge, bottomRight, wallWidth(bottomLeft), wallWidth(bottomRight), lineWidth, bottomEdgeWidth, bottomLabels)
+	if err != nil {
+		return "", err
+	}
+	space, err := b.labelSpace(p)
+	if err != nil {
+		return "", err
+	}
+	TopBar, BottomBar = frameLabels(TopBar, topLabels, space), frameLabels(BottomBar, bottomLabels, space)
+	// A gradient or part colors color the bars once the height of the box is
+	// known.
+	if !b.paintsByPosition() {
+		if TopBar, err = applyColor(TopBar, b.color, p); err != nil {
+			return "", err
+		}
+		if BottomBar, err = applyColor(BottomBar, b.color, p); err != nil {
+			return "", err
+		}
 
-	// Check b.TitlePos if it is not Inside
-	if b.titlePos != Inside {
-		switch b.titlePos {
-		case Top:
-			TopBar = buildTitledBar(b.topLeft, b.horizontal, b.topRight, topLeftWidth, topRightWidth, lineWidth, horizontalWidth, title)
-		case Bottom:
-			BottomBar = buildTitledBar(b.bottomLeft, b.horizontal, b.bottomRight, bottomLeftWidth, bottomRightWidth, lineWidth, horizontalWidth, title)
-		default:
-			return "", fmt.Errorf("invalid TitlePosition %s", b.titlePos)
+		// Restore the border color around colored labels once per bar.
+		if TopBar, BottomBar, err = b.applyColorBar(TopBar, BottomBar, topLabels, bottomLabels, p); err != nil {
+			return "", err
 		}
 	}
-	if TopBar, err = applyColor(TopBar, b.color); err != nil {
+
+	// Create lines to print
+	topPadding, bottomPadding, err := b.addVertPadding(innerWidth, p)
+	if err != nil {
 		return "", err
 	}
-	if BottomBar, err = applyColor(BottomBar, b.color); err != nil {
+	formatted, err := b.formatLine(lines2, _longestLine, titleLen, leftMargin, rightMargin, title, nil, p)
+	if err != nil {
 		return "", err
 	}
+	plainDivider, divider := "", ""
+	if len(dividers) > 0 {
+		dividerGlyph := glyphOr(b.divider, b.horizontal)
+		leftTee := glyphIf(glyphOr(b.leftTee, leftWall), leftWall != "")
+		rightTee := glyphIf(glyphOr(b.rightTee, rightWall), rightWall != "")
+		plainDivider = buildPlainBar(leftTee, dividerGlyph, rightTee, wallWidth(leftTee), wallWidth(rightTee), lineWidth, charWidth(dividerGlyph))
+		if divider, err = b.paintChrome(plainDivider, p); err != nil {
+			return "", err
+		}
+	}
 
-	// Apply title coloring to the bars once, expanding tabs in the title if needed.
-	titleForBar := title
-	if strings.Contains(titleForBar, "\t") {
-		titleForBar = xstrings.ExpandTabs(titleForBar, 4)
+	var body []string
+	// dividerRows holds the indexes of the divider lines in body.
+	var dividerRows []int
+	for i, line := range formatted {
+		if slices.Contains(dividers, i) {
+			body = append(body, bottomPadding...)
+			dividerRows = append(dividerRows, len(body))
+			body = append(body, divider)
+			body = append(body, topPadding...)
+		}
+		body = append(body, line)
 	}
-	if TopBar, BottomBar, err = b.applyColorBar(TopBar, BottomBar, titleForBar); err != nil {
+	// Fill up to MinHeight with blank lines placed according to VerticalAlign.
+	fill := max(b.minHeight-barRows-len(body)-len(topPadding)-len(bottomPadding), 0)
+	above, below, err := vertOffsets(len(body), len(body)+fill, b.verticalAlign)
+	if err != nil {
 		return "", err
 	}
-
-	// Create lines to print
-	texts, err := b.addVertPadding(innerWidth)
+	aboveLines, err := b.blankLines(above, innerWidth, p)
 	if err != nil {
 		return "", err
 	}
-	texts, err = b.formatLine(lines2, _longestLine, titleLen, sideMargin, title, texts)
+	belowLines, err := b.blankLines(below, innerWidth, p)
 	if err != nil {
 		return "", err
 	}
-	vertPadding, err := b.addVertPadding(innerWidth)
+
+	texts := append([]string{}, topPadding...)
+	texts = append(texts, aboveLines...)
+	texts = append(texts, body...)
+	texts = append(texts, belowLines...)
+	texts = append(texts, bottomPadding...)
+
+	painter, err := b.borderPainter(len(texts)+barRows, lineWidth, p)
 	if err != nil {
 		return "", err
 	}
-	texts = append(texts, vertPadding...)
+	if painter != nil {
+		// Bars start at the first row, unless the top bar is hidden.
+		row := 0
+		if !b.hideTop {
+			TopBar = painter.paint(TopBar, 0, 0)
+			row = 1
+		}
+		BottomBar = painter.paint(BottomBar, len(texts)+barRows-1, 0)
+
+		leftSep, err := b.paintChrome(leftWall, p)
+		if err != nil {
+			return "", err
+		}
+		rightSep, err := b.paintChrome(rightWall, p)
+		if err != nil {
+			return "", err
+		}
+		bodyStart := len(topPadding) + len(aboveLines)
+		for i, line := range texts {
+			y := row + i
+			if slices.Contains(dividerRows, i-bodyStart) {
+				if texts[i], err = applyBackground(painter.paint(plainDivider, y, 0), b.background, p); err != nil {
+					return "", err
+				}
+				continue
+			}
+			inner := line[len(leftSep) : len(line)-len(rightSep)]
+			left, err := applyBackground(painter.paint(leftWall, y, 0), b.background, p)
+			if err != nil {
+				return "", err
+			}
+			right, err := applyBackground(painter.paint(rightWall, y, lineWidth-wallWidth(rightWall)), b.background, p)
+			if err != nil {
+				return "", err
+			}
+			texts[i] = left + inner + right
+		}
+	}
+	// The background runs under the labels too.
+	if TopBar, err = applyBackground(TopBar, b.background, p); err != nil {
+		return "", err
+	}
+	if BottomBar, err = applyBackground(BottomBar, b.background, p); err != nil {
+		return "", err
+	}
 
 	var sb strings.Builder
 
-	sb.WriteString(TopBar)
-	sb.WriteString("\n")
+	if !b.hideTop {
+		sb.WriteString(TopBar)
+		sb.WriteString("\n")
+	}
 	sb.WriteString(strings.Join(texts, "\n"))
 	sb.WriteString("\n")
-	sb.WriteString(BottomBar)
-	sb.WriteString("\n")
+	if !b.hideBottom {
+		sb.WriteString(BottomBar)
+		sb.WriteString("\n")
+	}
+
+	return b.applyMargin(sb.String()), nil
+}
 
-	return sb.String(), nil
+// sideWalls returns the glyphs of the left and right walls, falling back to
+// the Vertical glyph; hidden walls are empty.
+func (b *Box) sideWalls() (string, string) {
+	return glyphIf(glyphOr(b.leftEdge, b.vertical), !b.hideLeft), glyphIf(glyphOr(b.rightEdge, b.vertical), !b.hideRight)
+}
+
+// barRows returns the number of lines taken by the visible top and bottom
+// bars.
+func (b *Box) barRows() int {
+	rows := 0
+	if !b.hideTop {
+		rows++
+	}
+	if !b.hideBottom {
+		rows++
+	}
+	return rows
+}
 
+// glyphIf returns glyph when visible is true and "" otherwise.
+func glyphIf(glyph string, visible bool) string {
+	if !visible {
+		return ""
+	}
+	return glyph
+}
+
+// wallWidth returns the visible width of a border glyph, 0 when it is
+// hidden.
+func wallWidth(glyph string) int {
+	if glyph == "" {
+		return 0
+	}
+	return charWidth(glyph)
+}
+
+// borderLabels returns the colored labels of the top and bottom borders in
+// slot order. A Top or Bottom title and the footer occupy the slot matching
+// their alignment.
+func (b *Box) borderLabels(title, footer string, titlePos TitlePosition, p colorprofile.Profile) ([3]string, [3]string, error) {
+	top, err := b.edgeLabels(b.topLabels, p)
+	if err != nil {
+		return top, top, err
+	}
+	bottom, err := b.edgeLabels(b.bottomLabels, p)
+	if err != nil {
+		return top, bottom, err
+	}
+	top, bottom, errs := b.placeLabels(top, bottom, title, footer, titlePos)
+	return top, bottom, errors.Join(errs...)
+}
+
+// edgeLabels colors the labels of one border and orders them by slot.
+func (b *Box) edgeLabels(labels map[AlignType]string, p colorprofile.Profile) ([3]string, error) {
+	out := slotLabels(labels)
+	for i, label := range out {
+		if label == "" {
+			continue
+		}
+		colored, err := applyColor(label, b.labelColor, p)
+		if err != nil {
+			return out, err
+		}
+		out[i] = colored
+	}
+	return out, nil
+}
+
+// slotLabels orders the labels of one border by slot, skipping labels in
+// invalid slots; validate reports them.
+func slotLabels(labels map[AlignType]string) [3]string {
+	var out [3]string
+	for slot, label := range labels {
+		if i, ok := slotIndex(slot); ok && slot != "" {
+			out[i] = label
+		}
+	}
+	return out
+}
+
+// placeLabels adds a Top or Bottom title and the footer to the top and
+// bottom labels, reporting every label that conflicts with another one or
+// sits on a hidden border.
+func (b *Box) placeLabels(top, bottom [3]string, title, footer string, titlePos TitlePosition) ([3]string, [3]string, []error) {
+	var errs []error
+	if title != "" && (titlePos == Top || titlePos == Bottom) {
+		labels, edge := &top, "top"
+		if titlePos == Bottom {
+			labels, edge = &bottom, "bottom"
+		}
+		if err := placeLabel(labels, b.titleAlign, title, "title", edge); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if footer != "" {
+		if err := placeLabel(&bottom, b.footerAlign, footer, "footer", "bottom"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if b.hideTop && top != [3]string{} {
+		errs = append(errs, configError("Borders", top, ErrConflict, "cannot place labels on the hidden top border"))
+	}
+	if b.hideBottom && bottom != [3]string{} {
+		errs = append(errs, configError("Borders", bottom, ErrConflict, "cannot place labels on the hidden bottom border"))
+	}
+	return top, bottom, errs
+}
+
+// placeLabel puts label in the slot matching align, failing if a label
+// already occupies it. Labels with an invalid alignment are skipped;
+// validate reports them.
+func placeLabel(labels *[3]string, align AlignType, label, name, edge string) error {
+	i, ok := slotIndex(align)
+	if !ok {
+		return nil
+	}
+	if labels[i] != "" {
+		field := "TitleAlign"
+		if name == "footer" {
+			field = "FooterAlign"
+		}
+		return configError(field, align, ErrConflict, "%s conflicts with the %s label in the %s slot", name, edge, labelSlots[i])
+	}
+	labels[i] = label
+	return nil
+}
+
+// outputProfile returns the color profile used when rendering for w.
+//
+// An explicit profile set with ColorProfile always wins. Otherwise the
+// profile is detected from w. For os.Stdout, outputs without color support
+// still receive full colors so that redirected output keeps its styling, as
+// Render always did; other writers, such as files, buffers and network
+// connections, get the detected profile and so stay plain unless they are
+// terminals or the environment forces colors.
+func (b *Box) outputProfile(w io.Writer) colorprofile.Profile {
+	if b.profile != colorprofile.Unknown {
+		return b.profile
+	}
+	if w != io.Writer(os.Stdout) {
+		return colorprofile.Detect(w, os.Environ())
+	}
+	if profile <= colorprofile.ASCII {
+		return colorprofile.TrueColor
+	}
+	return profile
+}
+
+// termWidth returns the width of the terminal backing w.
+func termWidth(w io.Writer) (int, error) {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok || !isTTY(f.Fd()) {
+		return 0, fmt.Errorf("%w; use WrapLimit to set an explicit wrap limit when wrapping on non-TTY outputs", ErrNoTerminalWidth)
+	}
+	width, _, err := term.GetSize(f.Fd())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrNoTerminalWidth, err)
+	}
+	return width, nil
 }
@@ -1,9 +1,15 @@
 package box
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 )
@@ -41,16 +47,9 @@ func TestRenderBasicBox(t *testing.T) {
 	}
 }
 func TestRenderInbuiltStylesPorts(t *testing.T) {
-	tests := []BoxStyle{
-		Single,
-		SingleDouble,
-		Double,
-		DoubleSingle,
-		Bold,
-		Round,
-		Hidden,
-		Classic,
-		Block,
+	tests := Styles()
+	if len(tests) != len(boxes) {
+		t.Fatalf("expected Styles to list all %d presets, got %d", len(boxes), len(tests))
 	}
 
 	for _, style := range tests {
@@ -74,10 +73,10 @@ func TestRenderInbuiltStylesPorts(t *testing.T) {
 		top := lines[0]
 		bottom := lines[len(lines)-2]
 
-		if !strings.HasPrefix(top, preset.topLeft) || !strings.HasSuffix(top, preset.topRight) {
+		if !strings.HasPrefix(top, preset.TopLeft) || !strings.HasSuffix(top, preset.TopRight) {
 			t.Errorf("style %q: unexpected top corners: %q", style, top)
 		}
-		if !strings.HasPrefix(bottom, preset.bottomLeft) || !strings.HasSuffix(bottom, preset.bottomRight) {
+		if !strings.HasPrefix(bottom, preset.BottomLeft) || !strings.HasSuffix(bottom, preset.BottomRight) {
 			t.Errorf("style %q: unexpected bottom corners: %q", style, bottom)
 		}
 
@@ -89,7 +88,7 @@ func TestRenderInbuiltStylesPorts(t *testing.T) {
 			if len(mid) == 0 {
 				t.Errorf("style %q: mid interior line unexpectedly empty", style)
 			} else {
-				if !strings.HasPrefix(mid, preset.vertical) || !strings.HasSuffix(mid, preset.vertical) {
+				if !strings.HasPrefix(mid, glyphOr(preset.LeftEdge, preset.Vertical)) || !strings.HasSuffix(mid, glyphOr(preset.RightEdge, preset.Vertical)) {
 					t.Errorf("style %q: unexpected vertical borders in interior line: %q", style, mid)
 				}
 			}
@@ -97,6 +96,48 @@ func TestRenderInbuiltStylesPorts(t *testing.T) {
 	}
 }
 
+func TestRenderAdditionalStyles(t *testing.T) {
+	cases := []struct {
+		style BoxStyle
+		want  string
+	}{
+		{Dashed, "┌ T ┄┄┐\n┆ one ┆\n├┄┄┄┄┄┤\n┆ two ┆\n└┄┄┄┄┄┘\n"},
+		{DashedBold, "┏ T ┅┅┓\n┇ one ┇\n┣┅┅┅┅┅┫\n┇ two ┇\n┗┅┅┅┅┅┛\n"},
+		{Dotted, "┌ T ┈┈┐\n┊ one ┊\n├┈┈┈┈┈┤\n┊ two ┊\n└┈┈┈┈┈┘\n"},
+		{DottedBold, "┏ T ┉┉┓\n┋ one ┋\n┣┉┉┉┉┉┫\n┋ two ┋\n┗┉┉┉┉┉┛\n"},
+		{RoundBold, "╭ T ━━╮\n┃ one ┃\n┣━━━━━┫\n┃ two ┃\n╰━━━━━╯\n"},
+		{InnerHalfBlock, "▗ T ▄▄▖\n▐ one ▌\n▐▄▄▄▄▄▌\n▐ two ▌\n▝▀▀▀▀▀▘\n"},
+		{OuterHalfBlock, "▛ T ▀▀▜\n▌ one ▐\n▌▀▀▀▀▀▐\n▌ two ▐\n▙▄▄▄▄▄▟\n"},
+		{Markdown, "| T --|\n| one |\n|-----|\n| two |\n|-----|\n"},
+		{RST, "= T ===\n  one  \n=======\n  two  \n=======\n"},
+		{Ascii, ". T --.\n| one |\n:-----:\n| two |\n'-----'\n"},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.style), func(t *testing.T) {
+			out, err := NewBox().Style(tc.style).Padding(1, 0).TitlePosition(Top).RenderSections("T", "one", "two")
+			if err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			if out != tc.want {
+				t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, tc.want)
+			}
+		})
+	}
+}
+
+func TestStylesAreCopies(t *testing.T) {
+	styles := Styles()
+	styles[0] = "Changed"
+	if Styles()[0] != Single {
+		t.Errorf("expected Styles to return a copy, got %v", Styles())
+	}
+	for _, style := range Styles() {
+		if _, ok := boxes[style]; !ok {
+			t.Errorf("style %q listed without a preset", style)
+		}
+	}
+}
+
 func TestRenderDefaultStyleWithoutExplicitStyle(t *testing.T) {
 	b := NewBox().Padding(1, 1)
 
@@ -175,8 +216,8 @@ func TestBoxCopy(t *testing.T) {
 		if original.color != Red {
 			t.Fatalf("expected original color to remain Red, got %q", original.color)
 		}
-		if original.px != 1 || original.py != 2 {
-			t.Fatalf("expected original padding (1,2), got (%d,%d)", original.px, original.py)
+		if original.padLeft != 1 || original.padRight != 1 || original.padTop != 2 || original.padBottom != 2 {
+			t.Fatalf("expected original padding (1,2), got (%d,%d)", original.padLeft, original.padTop)
 		}
 		if original.titlePos != Top {
 			t.Fatalf("expected original title position to stay Top, got %v", original.titlePos)
@@ -202,24 +243,24 @@ func TestBoxCopy(t *testing.T) {
 func TestHPaddingAndVPadding(t *testing.T) {
 	b := NewBox().Padding(1, 2)
 
-	if b.px != 1 || b.py != 2 {
-		t.Fatalf("expected initial padding (1,2), got (%d,%d)", b.px, b.py)
+	if b.padLeft != 1 || b.padRight != 1 || b.padTop != 2 || b.padBottom != 2 {
+		t.Fatalf("expected initial padding (1,2), got (%d,%d)", b.padLeft, b.padTop)
 	}
 
 	b.HPadding(5)
-	if b.px != 5 {
-		t.Errorf("expected HPadding to set horizontal padding to 5, got %d", b.px)
+	if b.padLeft != 5 || b.padRight != 5 {
+		t.Errorf("expected HPadding to set horizontal padding to 5, got (%d,%d)", b.padLeft, b.padRight)
 	}
-	if b.py != 2 {
-		t.Errorf("expected HPadding to leave vertical padding unchanged at 2, got %d", b.py)
+	if b.padTop != 2 || b.padBottom != 2 {
+		t.Errorf("expected HPadding to leave vertical padding unchanged at 2, got (%d,%d)", b.padTop, b.padBottom)
 	}
 
 	b.VPadding(7)
-	if b.py != 7 {
-		t.Errorf("expected VPadding to set vertical padding to 7, got %d", b.py)
+	if b.padTop != 7 || b.padBottom != 7 {
+		t.Errorf("expected VPadding to set vertical padding to 7, got (%d,%d)", b.padTop, b.padBottom)
 	}
-	if b.px != 5 {
-		t.Errorf("expected VPadding to leave horizontal padding unchanged at 5, got %d", b.px)
+	if b.padLeft != 5 || b.padRight != 5 {
+		t.Errorf("expected VPadding to leave horizontal padding unchanged at 5, got (%d,%d)", b.padLeft, b.padRight)
 	}
 }
 
@@ -456,6 +497,18 @@ func TestRenderInvalidColors(t *testing.T) {
 			name: "invalid border color",
 			mut:  func(b *Box) { b.Color("NotAColor") },
 		},
+		{
+			name: "invalid background",
+			mut:  func(b *Box) { b.Background("NotAColor") },
+		},
+		{
+			name: "invalid content background",
+			mut:  func(b *Box) { b.ContentBackground("NotAColor") },
+		},
+		{
+			name: "invalid title background",
+			mut:  func(b *Box) { b.TitleBackground("NotAColor") },
+		},
 	}
 
 	for _, tc := range tests {
@@ -548,3 +601,1289 @@ func TestRenderBoxCustomGlyphsWithoutNewBoxMethod(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderToWriter(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewBox().Padding(1, 0).Style(Single)
+
+	if err := b.RenderTo(&buf, "Title", "Content"); err != nil {
+		t.Fatalf("RenderTo returned error: %v", err)
+	}
+	want, err := b.Render("Title", "Content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("RenderTo output differs from Render:\ngot  %q\nwant %q", buf.String(), want)
+	}
+
+	// Wrapping without a limit needs a terminal; a buffer is not one.
+	buf.Reset()
+	if err := b.Copy().WrapContent(true).RenderTo(&buf, "Title", "Content"); err == nil {
+		t.Fatalf("expected error when wrapping without a limit on a non-TTY writer")
+	} else if !strings.Contains(err.Error(), "cannot determine terminal width") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written on error, got %q", buf.String())
+	}
+}
+
+func TestRenderSectionsTo(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	b := NewBox().Padding(1, 0)
+
+	var buf bytes.Buffer
+	if err := b.RenderSectionsTo(&buf, "Title", "Header", "Body"); err != nil {
+		t.Fatalf("RenderSectionsTo returned error: %v", err)
+	}
+	want, err := b.RenderSections("Title", "Header", "Body")
+	if err != nil {
+		t.Fatalf("RenderSections returned error: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("RenderSectionsTo output differs from RenderSections:\ngot  %q\nwant %q", buf.String(), want)
+	}
+
+	// A buffer is not a terminal, so it gets plain text.
+	buf.Reset()
+	if err := b.Copy().Color(Red).RenderSectionsTo(&buf, "Title", "Header", "Body"); err != nil {
+		t.Fatalf("RenderSectionsTo returned error: %v", err)
+	}
+	if out := buf.String(); out == "" || ansi.Strip(out) != out {
+		t.Errorf("expected plain text for a buffer, got %q", out)
+	}
+
+	buf.Reset()
+	if err := b.Copy().Color("NotAColor").RenderSectionsTo(&buf, "Title", "Header"); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written on error, got %q", buf.String())
+	}
+}
+
+func TestRenderToDetectsProfile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	b := NewBox().Color(Red).TitleColor(Green).Background(Blue)
+
+	// A buffer is not a terminal, so it gets plain text.
+	var buf bytes.Buffer
+	if err := b.RenderTo(&buf, "Title", "Content"); err != nil {
+		t.Fatalf("RenderTo returned error: %v", err)
+	}
+	if out := buf.String(); ansi.Strip(out) != out {
+		t.Errorf("expected no escape sequences for a buffer, got %q", out)
+	}
+
+	// The environment can still force colors.
+	t.Setenv("CLICOLOR_FORCE", "1")
+	buf.Reset()
+	if err := b.RenderTo(&buf, "Title", "Content"); err != nil {
+		t.Fatalf("RenderTo returned error: %v", err)
+	}
+	if out := buf.String(); ansi.Strip(out) == out {
+		t.Errorf("expected colors with CLICOLOR_FORCE, got %q", out)
+	}
+}
+
+func TestRenderWithExplicitColorProfile(t *testing.T) {
+	base := NewBox().Padding(1, 0).Style(Single).Color("#8B75FF").TitleColor(Green).ContentColor(BrightRed)
+
+	t.Run("ascii", func(t *testing.T) {
+		out, err := base.Copy().ColorProfile(colorprofile.Ascii).Render("Title", "Content")
+		if err != nil {
+			t.Fatalf("Render returned error: %v", err)
+		}
+		if ansi.Strip(out) != out {
+			t.Errorf("expected no escape sequences with Ascii profile, got %q", out)
+		}
+	})
+
+	t.Run("ansi", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := base.Copy().ColorProfile(colorprofile.ANSI).RenderTo(&buf, "Title", "Content"); err != nil {
+			t.Fatalf("RenderTo returned error: %v", err)
+		}
+		out := buf.String()
+		if strings.Contains(out, "38;2;") {
+			t.Errorf("expected truecolor sequences to be degraded with ANSI profile, got %q", out)
+		}
+		if ansi.Strip(out) == out {
+			t.Errorf("expected colored output with ANSI profile, got %q", out)
+		}
+	})
+
+	t.Run("truecolor", func(t *testing.T) {
+		out, err := base.Copy().ColorProfile(colorprofile.TrueColor).Render("Title", "Content")
+		if err != nil {
+			t.Fatalf("Render returned error: %v", err)
+		}
+		if !strings.Contains(out, "38;2;") {
+			t.Errorf("expected truecolor sequences with TrueColor profile, got %q", out)
+		}
+	})
+}
+
+func TestRenderNestedRigidBox(t *testing.T) {
+	inner := NewBox().Style(Round).Padding(1, 0).Color(Cyan)
+	block, err := inner.RenderRigid("Inner", "nested content that is long")
+	if err != nil {
+		t.Fatalf("RenderRigid returned error: %v", err)
+	}
+
+	outer := NewBox().Style(Double).Padding(1, 0).ContentAlign(Center).WrapLimit(10)
+	out, err := outer.Render("Outer", "some text to wrap around\n"+block)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if strings.Contains(out, rigidMarker) {
+		t.Errorf("expected rigid markers to be removed from output, got %q", out)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(ansi.Strip(out), "\n"), "\n")
+	width := runewidth.StringWidth(lines[0])
+	for i, l := range lines {
+		if w := runewidth.StringWidth(l); w != width {
+			t.Errorf("line %d: expected width %d, got %d: %q", i, width, w, l)
+		}
+	}
+
+	// The inner box must not be broken by the outer wrap limit and its lines
+	// must share the same offset when centered.
+	innerLines := strings.Split(strings.TrimSuffix(ansi.Strip(inner.MustRender("Inner", "nested content that is long")), "\n"), "\n")
+	offset := -1
+	for _, want := range innerLines {
+		found := false
+		for _, l := range lines {
+			if idx := strings.Index(l, want); idx != -1 {
+				if offset == -1 {
+					offset = idx
+				} else if idx != offset {
+					t.Errorf("inner line %q at offset %d, expected %d", want, idx, offset)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("inner box line %q not found intact in output:\n%s", want, ansi.Strip(out))
+		}
+	}
+
+	// Text outside the rigid block is still wrapped.
+	if strings.Contains(ansi.Strip(out), "some text to wrap around") {
+		t.Errorf("expected surrounding text to be wrapped, got:\n%s", ansi.Strip(out))
+	}
+}
+
+func TestRigidPadsBlockToRectangle(t *testing.T) {
+	got := Rigid("ab\nabcd\n")
+	want := rigidMarker + "ab  \n" + rigidMarker + "abcd"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderSections(t *testing.T) {
+	b := NewBox().Style(Single).Padding(1, 0)
+	out, err := b.RenderSections("", "Header", "Body line\nmore body", "Footer")
+	if err != nil {
+		t.Fatalf("RenderSections returned error: %v", err)
+	}
+
+	want := "" +
+		"┌───────────┐\n" +
+		"│ Header    │\n" +
+		"├───────────┤\n" +
+		"│ Body line │\n" +
+		"│ more body │\n" +
+		"├───────────┤\n" +
+		"│ Footer    │\n" +
+		"└───────────┘\n"
+	if out != want {
+		t.Errorf("unexpected sections output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRenderSectionsDividerGlyphs(t *testing.T) {
+	cases := []struct {
+		style BoxStyle
+		want  string
+	}{
+		{Single, "├──────┤"},
+		{Double, "╠══════╣"},
+		{Classic, "+------+"},
+		{Bold, "┣━━━━━━┫"},
+	}
+	for _, tc := range cases {
+		out, err := NewBox().Style(tc.style).Padding(1, 1).RenderSections("", "head", "body")
+		if err != nil {
+			t.Fatalf("style %q: RenderSections returned error: %v", tc.style, err)
+		}
+		lines := strings.Split(out, "\n")
+		// Top bar, padding, "head", padding, divider.
+		if lines[4] != tc.want {
+			t.Errorf("style %q: expected divider %q surrounded by padding, got:\n%s", tc.style, tc.want, out)
+		}
+	}
+
+	// Custom divider glyphs override the preset.
+	out, err := NewBox().Style(Single).LeftTee("<").RightTee(">").Divider("~").RenderSections("", "a", "b")
+	if err != nil {
+		t.Fatalf("RenderSections returned error: %v", err)
+	}
+	if !strings.Contains(out, "\n<~>\n") {
+		t.Errorf("expected custom divider glyphs, got:\n%s", out)
+	}
+}
+
+func TestRenderSectionsWithEmojiBordersAndInsideTitle(t *testing.T) {
+	b := NewBox().Padding(2, 1).Color(Green)
+	b.TopLeft("📦").TopRight("📦").BottomLeft("📦").BottomRight("📦").Horizontal("📦").Vertical("📦").LeftTee("📦").RightTee("📦").Divider("📦")
+
+	out, err := b.RenderSections("Title", "first section", "second")
+	if err != nil {
+		t.Fatalf("RenderSections returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(ansi.Strip(out), "\n"), "\n")
+	width := runewidth.StringWidth(lines[0])
+	for i, l := range lines {
+		if w := runewidth.StringWidth(l); w != width {
+			t.Errorf("line %d: expected width %d, got %d: %q", i, width, w, l)
+		}
+	}
+	if !strings.Contains(lines[1+1], "Title") {
+		t.Errorf("expected Inside title before the first section, got:\n%s", ansi.Strip(out))
+	}
+}
+
+func TestRenderTitleAlign(t *testing.T) {
+	cases := []struct {
+		name  string
+		pos   TitlePosition
+		align AlignType
+		want  string
+	}{
+		{"top default", Top, "", "┌ Title ──────────────┐"},
+		{"top center", Top, Center, "┌─────── Title ───────┐"},
+		{"top right", Top, Right, "┌────────────── Title ┐"},
+		{"bottom center", Bottom, Center, "└─────── Title ───────┘"},
+		{"bottom right", Bottom, Right, "└────────────── Title ┘"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NewBox().Padding(1, 0).TitlePosition(tc.pos).TitleAlign(tc.align).Render("Title", "some longer content")
+			if err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			lines := strings.Split(out, "\n")
+			bar := lines[0]
+			if tc.pos == Bottom {
+				bar = lines[len(lines)-2]
+			}
+			if bar != tc.want {
+				t.Errorf("expected bar %q, got %q", tc.want, bar)
+			}
+		})
+	}
+
+	t.Run("inside right", func(t *testing.T) {
+		out, err := NewBox().Padding(1, 0).TitleAlign(Right).Render("Title", "some longer content")
+		if err != nil {
+			t.Fatalf("Render returned error: %v", err)
+		}
+		if line := strings.Split(out, "\n")[1]; line != "│               Title │" {
+			t.Errorf("expected right-aligned inside title, got %q", line)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewBox().TitlePosition(Top).TitleAlign(AlignType("Weird")).Render("Title", "Content")
+		if err == nil || !strings.Contains(err.Error(), "invalid Title Alignment") {
+			t.Errorf("expected invalid Title Alignment error, got %v", err)
+		}
+	})
+}
+
+func TestRenderTitleAlignEmojiBorders(t *testing.T) {
+	for _, align := range []AlignType{Left, Center, Right} {
+		b := NewBox().Padding(2, 0).TitlePosition(Top).TitleAlign(align)
+		b.TopLeft("📦").TopRight("📦").BottomLeft("📦").BottomRight("📦").Horizontal("📦").Vertical("📦")
+
+		out, err := b.Render("Odd", "With emoji borders!")
+		if err != nil {
+			t.Fatalf("%s: Render returned error: %v", align, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		top := ansi.Strip(lines[0])
+		if w, want := runewidth.StringWidth(top), runewidth.StringWidth(ansi.Strip(lines[1])); w != want {
+			t.Errorf("%s: expected top bar width %d, got %d: %q", align, want, w, top)
+		}
+		// The glyphs next to the corners are never replaced by gap spaces.
+		if !strings.HasPrefix(top, "📦📦") && align != Left {
+			t.Errorf("%s: expected fill glyph after the left corner, got %q", align, top)
+		}
+		if !strings.HasSuffix(top, "📦📦") && align != Right {
+			t.Errorf("%s: expected fill glyph before the right corner, got %q", align, top)
+		}
+	}
+}
+
+func TestRenderFooter(t *testing.T) {
+	b := NewBox().Padding(1, 0).TitlePosition(Top).Footer("3 warnings").FooterAlign(Right)
+	out, err := b.Render("Build", "everything is fine")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌ Build ─────────────┐\n" +
+		"│ everything is fine │\n" +
+		"└──────── 3 warnings ┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// The box widens to whichever of title and footer is longer.
+	out, err = NewBox().Padding(1, 0).TitlePosition(Top).Footer("a much longer footer").Render("T", "ok")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if lines[len(lines)-1] != "└ a much longer footer ┘" {
+		t.Errorf("expected box widened to fit footer, got:\n%s", out)
+	}
+	if runewidth.StringWidth(lines[0]) != runewidth.StringWidth(lines[len(lines)-1]) {
+		t.Errorf("expected top and bottom bars to have equal widths:\n%s", out)
+	}
+}
+
+func TestRenderFooterColors(t *testing.T) {
+	b := NewBox().Padding(1, 0).TitlePosition(Top).Color(Blue).TitleColor(Yellow).
+		Footer("status").FooterColor(Red).ColorProfile(colorprofile.TrueColor)
+	out, err := b.Render("Title", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	bottom := lines[len(lines)-1]
+
+	red, _ := applyColor("status", Red, colorprofile.TrueColor)
+	if !strings.Contains(bottom, red) {
+		t.Errorf("expected footer colored red in bottom bar, got %q", bottom)
+	}
+	// The border color is restored after the footer.
+	if idx := strings.Index(bottom, red); !strings.Contains(bottom[idx+len(red):], "38;2;0;0;128") {
+		t.Errorf("expected border color after footer, got %q", bottom)
+	}
+}
+
+func TestRenderFooterWithBottomTitle(t *testing.T) {
+	out, err := NewBox().Padding(1, 0).TitlePosition(Bottom).Footer("v1").FooterAlign(Right).Render("Title", "Content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if want := "└ Title ─ v1 ┘"; lines[len(lines)-1] != want {
+		t.Errorf("unexpected bottom bar:\ngot:  %q\nwant: %q", lines[len(lines)-1], want)
+	}
+}
+
+func TestRenderFooterErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		box  *Box
+		want string
+	}{
+		{"multiline", NewBox().Footer("a\nb"), "multiline footers are not supported"},
+		{"bottom title in the same slot", NewBox().TitlePosition(Bottom).Footer("f"), "footer conflicts with the bottom label in the Left slot"},
+		{"invalid align", NewBox().Footer("f").FooterAlign(AlignType("Weird")), "invalid Footer Alignment"},
+		{"invalid color", NewBox().Footer("f").FooterColor("NotAColor"), "unable to parse color"},
+	}
+	for _, tc := range cases {
+		if _, err := tc.box.Render("Title", "Content"); err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		} else if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: unexpected error message: %v", tc.name, err)
+		}
+	}
+}
+
+func TestRenderBorderLabels(t *testing.T) {
+	b := NewBox().Padding(1, 0).TitlePosition(Top).
+		TopLabel(Center, "12/40").TopLabel(Right, "[main]").
+		BottomLabel(Left, "q quit").BottomLabel(Right, "? help")
+	out, err := b.Render("Files", "a long enough line of content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌ Files ───── 12/40 ──── [main] ┐\n" +
+		"│ a long enough line of content │\n" +
+		"└ q quit ─────────────── ? help ┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// The box widens so that every label fits with fill between them.
+	out, err = NewBox().TopLabel(Left, "left").TopLabel(Center, "mid").TopLabel(Right, "right").Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want = "" +
+		"┌ left ─ mid ─ right ┐\n" +
+		"│x                   │\n" +
+		"└────────────────────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Clearing a slot removes its label.
+	out, err = NewBox().TopLabel(Right, "gone").TopLabel(Right, "").Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "┌─┐\n│x│\n└─┘\n" {
+		t.Errorf("expected cleared label to be dropped, got:\n%s", out)
+	}
+}
+
+func TestRenderBorderLabelColors(t *testing.T) {
+	b := NewBox().TitlePosition(Top).Color(Blue).TitleColor(Yellow).
+		TopLabel(Right, "[main]").LabelColor(Red).ColorProfile(colorprofile.TrueColor)
+	out, err := b.Render("Files", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	top := strings.Split(out, "\n")[0]
+	yellow, _ := applyColor("Files", Yellow, colorprofile.TrueColor)
+	red, _ := applyColor("[main]", Red, colorprofile.TrueColor)
+	if !strings.Contains(top, yellow) || !strings.Contains(top, red) {
+		t.Fatalf("expected title and label keep their colors, got %q", top)
+	}
+	// The border color is restored between and after the labels.
+	between := top[strings.Index(top, yellow)+len(yellow) : strings.Index(top, red)]
+	if !strings.Contains(between, "38;2;0;0;128") {
+		t.Errorf("expected border color between labels, got %q", between)
+	}
+	if !strings.Contains(top[strings.Index(top, red)+len(red):], "38;2;0;0;128") {
+		t.Errorf("expected border color after the last label, got %q", top)
+	}
+	if ansi.Strip(top) != "┌ Files ─ [main] ┐" {
+		t.Errorf("unexpected stripped top bar %q", ansi.Strip(top))
+	}
+}
+
+func TestRenderBackground(t *testing.T) {
+	b := NewBox().Padding(1, 1).MinHeight(6).Background(Blue).ContentBackground(Black).
+		TitleBackground(Red).ColorProfile(colorprofile.ANSI)
+	out, err := b.RenderSections("Title", "a\033[1mb\033[0mc", "longer")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	plain, _ := NewBox().Padding(1, 1).MinHeight(6).RenderSections("Title", "abc", "longer")
+	if ansi.Strip(out) != plain {
+		t.Fatalf("expected backgrounds not to change the layout, got:\n%s\nwant:\n%s", ansi.Strip(out), plain)
+	}
+
+	blue, black, red := "\x1b[44m", "\x1b[40m", "\x1b[41m"
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for _, i := range []int{0, 6, len(lines) - 1} {
+		if want := blue; !strings.HasPrefix(lines[i], want) {
+			t.Errorf("expected the border line %d on the box background, got %q", i, lines[i])
+		}
+	}
+	// Padding rows, alignment spaces and the Inside title fill the inner area.
+	if want := blue + "│\x1b[m" + black + "        \x1b[m" + blue + "│\x1b[m"; lines[1] != want {
+		t.Errorf("unexpected padding row:\ngot:  %q\nwant: %q", lines[1], want)
+	}
+	if want := blue + "│\x1b[m" + red + " Title  \x1b[m" + blue + "│\x1b[m"; lines[2] != want {
+		t.Errorf("unexpected title row:\ngot:  %q\nwant: %q", lines[2], want)
+	}
+	// The background is restored after the embedded reset.
+	if want := blue + "│\x1b[m" + black + " a\x1b[1mb\x1b[m" + black + "c    \x1b[m" + blue + "│\x1b[m"; lines[4] != want {
+		t.Errorf("unexpected content row:\ngot:  %q\nwant: %q", lines[4], want)
+	}
+}
+
+func TestRenderBackgroundDefaults(t *testing.T) {
+	// ContentBackground and TitleBackground default to Background.
+	out, err := NewBox().Background(Blue).ContentColor(Green).ColorProfile(colorprofile.ANSI).Render("T", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if strings.Contains(out, "\x1b[40m") || strings.Count(out, "\x1b[44m") < 9 {
+		t.Errorf("expected the whole box on the Background color, got %q", out)
+	}
+	// The content color survives inside the background.
+	if !strings.Contains(out, "\x1b[44m\x1b[32mx\x1b[m") {
+		t.Errorf("expected the colored content on the background, got %q", out)
+	}
+
+	// A Top title colors only its own text; labels sit on the border background.
+	out, err = NewBox().TitlePosition(Top).Background(Blue).TitleBackground(Red).TopLabel(Right, "v1").
+		ColorProfile(colorprofile.ANSI).Render("T", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	top := strings.Split(out, "\n")[0]
+	if want := "\x1b[44m┌ \x1b[41mT\x1b[m\x1b[44m ─ v1 ┐\x1b[m"; top != want {
+		t.Errorf("unexpected top bar:\ngot:  %q\nwant: %q", top, want)
+	}
+}
+
+func TestRenderTextAttrs(t *testing.T) {
+	b := NewBox().TitleStyle(AttrBold | AttrUnderline).TitleColor(Red).ContentStyle(AttrItalic).ColorProfile(colorprofile.ANSI)
+	out, err := b.Render("T", "a\033[0mb")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if want := "│\x1b[1;4m\x1b[31mT\x1b[m │"; lines[1] != want {
+		t.Errorf("unexpected title line:\ngot:  %q\nwant: %q", lines[1], want)
+	}
+	// The attributes are restored after the embedded reset.
+	if want := "│\x1b[3ma\x1b[m\x1b[3mb\x1b[m│"; lines[3] != want {
+		t.Errorf("unexpected content line:\ngot:  %q\nwant: %q", lines[3], want)
+	}
+
+	// Profiles without styling support render plain text.
+	out, err = b.Copy().ColorProfile(colorprofile.Ascii).Render("T", "ab")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if plain, _ := NewBox().Render("T", "ab"); out != plain {
+		t.Errorf("expected plain output for the Ascii profile, got %q", out)
+	}
+}
+
+func TestApplyAttrs(t *testing.T) {
+	tests := []struct {
+		attrs TextAttr
+		want  string
+	}{
+		{0, "x"},
+		{AttrBold, "\x1b[1mx\x1b[m"},
+		{AttrDim | AttrStrikethrough, "\x1b[2;9mx\x1b[m"},
+		{AttrBlink | AttrReverse, "\x1b[5;7mx\x1b[m"},
+	}
+	for _, tt := range tests {
+		if got := applyAttrs("x", tt.attrs, colorprofile.TrueColor); got != tt.want {
+			t.Errorf("applyAttrs(%b): expected %q, got %q", tt.attrs, tt.want, got)
+		}
+	}
+}
+
+func TestRenderBorderLabelErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		box  *Box
+		want string
+	}{
+		{"invalid slot", NewBox().TopLabel(AlignType("Middle"), "x"), "invalid Label slot Middle"},
+		{"multiline", NewBox().BottomLabel(Left, "a\nb"), "multiline labels are not supported"},
+		{"title conflict", NewBox().TitlePosition(Top).TopLabel(Left, "x"), "title conflicts with the top label in the Left slot"},
+		{"footer conflict", NewBox().Footer("f").FooterAlign(Right).BottomLabel(Right, "x"), "footer conflicts with the bottom label in the Right slot"},
+		{"invalid color", NewBox().TopLabel(Left, "x").LabelColor("NotAColor"), "unable to parse color"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.box.Render("T", "content")
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.want)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestCopyDoesNotShareLabels(t *testing.T) {
+	base := NewBox().TopLabel(Left, "base")
+	derived := base.Copy().TopLabel(Left, "derived")
+	out, err := base.Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(out, "base") || strings.Contains(out, "derived") {
+		t.Errorf("expected the original labels unchanged by the copy, got:\n%s", out)
+	}
+	if out, _ = derived.Render("", "x"); !strings.Contains(out, "derived") {
+		t.Errorf("expected the copy to use its own label, got:\n%s", out)
+	}
+}
+
+func TestRenderDoesNotMutate(t *testing.T) {
+	b := NewBox().Footer("v1").TopLabel(Right, "x").Width(20)
+	before := *b.Copy()
+	if _, err := b.RenderSections("Title", "a", "b"); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*b, before) {
+		t.Errorf("expected rendering to leave the box unchanged:\nbefore: %+v\nafter:  %+v", before, *b)
+	}
+
+	// Tables do not support the size options.
+	b = NewBox().Footer("v1").TopLabel(Right, "x")
+	before = *b.Copy()
+	if _, err := b.RenderTable("Title", NewTable().Row("a", "b")); err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*b, before) {
+		t.Errorf("expected table rendering to leave the box unchanged:\nbefore: %+v\nafter:  %+v", before, *b)
+	}
+}
+
+func TestRenderConcurrent(t *testing.T) {
+	base := NewBox().Style(Round).Padding(2, 1).Color(Cyan).TitleColor(Green).
+		Footer("v1").TopLabel(Right, "x").WrapContent(true).WrapLimit(30)
+	want, err := base.Render("Title", "shared content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if got, err := base.Render("Title", "shared content"); err != nil || got != want {
+					t.Errorf("unexpected concurrent render: %v\n%s", err, got)
+					return
+				}
+				var buf bytes.Buffer
+				if err := base.RenderTo(&buf, "Title", "other content"); err != nil {
+					t.Errorf("RenderTo returned error: %v", err)
+					return
+				}
+				if _, err := base.RenderSections("", "a", "b"); err != nil {
+					t.Errorf("RenderSections returned error: %v", err)
+					return
+				}
+				if _, err := base.RenderTable("", NewTable().Row("a", "b")); err != nil {
+					t.Errorf("RenderTable returned error: %v", err)
+					return
+				}
+				if err := base.Validate(); err != nil {
+					t.Errorf("Validate returned error: %v", err)
+					return
+				}
+				// Copies derived concurrently are independent of the base.
+				base.Copy().TopLabel(Center, fmt.Sprint(i)).TitlePosition(Top).MustRender("Title", "x")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRenderSectionsSharedSlice(t *testing.T) {
+	b := NewBox().ContentColor(Green).ContentStyle(AttrBold).ColorProfile(colorprofile.ANSI)
+	sections := []string{"a", "b"}
+	want, err := b.RenderSections("T", sections...)
+	if err != nil {
+		t.Fatalf("RenderSections returned error: %v", err)
+	}
+	if sections[0] != "a" || sections[1] != "b" {
+		t.Fatalf("expected the sections to be left untouched, got %q", sections)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if got, err := b.RenderSections("T", sections...); err != nil || got != want {
+					t.Errorf("unexpected render of a shared slice: %v\n%s", err, got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRenderFixedWidth(t *testing.T) {
+	out, err := NewBox().Padding(1, 0).Width(20).Render("", "a fairly long line that needs wrapping")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌──────────────────┐\n" +
+		"│ a fairly long    │\n" +
+		"│ line that needs  │\n" +
+		"│ wrapping         │\n" +
+		"└──────────────────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Short content is padded to the width.
+	out, err = NewBox().Width(8).Render("", "hi")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "┌──────┐\n│hi    │\n└──────┘\n" {
+		t.Errorf("expected content padded to the width, got:\n%s", out)
+	}
+
+	// MinWidth only pads and MaxWidth only limits.
+	out, _ = NewBox().MinWidth(6).MaxWidth(10).Render("", "hi")
+	if out != "┌────┐\n│hi  │\n└────┘\n" {
+		t.Errorf("expected MinWidth padding, got:\n%s", out)
+	}
+	out, _ = NewBox().MinWidth(6).MaxWidth(10).Render("", "a long line")
+	if out != "┌──────┐\n│a long│\n│line  │\n└──────┘\n" {
+		t.Errorf("expected MaxWidth to wrap the content, got:\n%s", out)
+	}
+}
+
+func TestRenderTruncate(t *testing.T) {
+	b := NewBox().Padding(1, 0).Width(12).Overflow(OverflowTruncate)
+	out, err := b.Render("", "a fairly long line\nshort\n界界界界界界")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌──────────┐\n" +
+		"│ a fairl… │\n" +
+		"│ short    │\n" +
+		"│ 界界界…  │\n" +
+		"└──────────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Escape sequences are kept intact and the ellipsis is configurable.
+	out, err = b.Ellipsis("...").ColorProfile(colorprofile.TrueColor).ContentColor(Red).Render("", "a fairly long line")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	line := strings.Split(out, "\n")[1]
+	if ansi.Strip(line) != "│ a fai... │" {
+		t.Errorf("unexpected truncated line %q", ansi.Strip(line))
+	}
+	if !strings.Contains(line, "\x1b[38;2;128;0;0m") || !strings.Contains(line, "\x1b[m") {
+		t.Errorf("expected the color sequences to survive truncation, got %q", line)
+	}
+}
+
+func TestRenderTruncateColored(t *testing.T) {
+	b := NewBox().ColorProfile(colorprofile.TrueColor).Width(16).Overflow(OverflowTruncate).
+		TitleColor(Red).ContentColor(Green)
+	out, err := b.Render("Title", "a long content line that is cut\nshort")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	// Color codes take no space, so the rows line up with the bars.
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if w := visibleWidth(line); w != 16 {
+			t.Errorf("line %q is %d cells wide, want 16", ansi.Strip(line), w)
+		}
+	}
+}
+
+func TestRenderFixedHeight(t *testing.T) {
+	out, err := NewBox().Height(5).Overflow(OverflowTruncate).Render("", "1\n2\n3\n4\n5")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "┌──┐\n│1 │\n│2 │\n│3…│\n└──┘\n" {
+		t.Errorf("unexpected truncated output:\n%s", out)
+	}
+
+	// Short content gets blank lines below it, inside the vertical padding.
+	out, err = NewBox().Padding(1, 1).Height(6).Render("", "hi")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌────┐\n" +
+		"│    │\n" +
+		"│ hi │\n" +
+		"│    │\n" +
+		"│    │\n" +
+		"└────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Section dividers that no longer fit are dropped with their sections.
+	out, err = NewBox().Height(5).RenderSections("", "a", "b", "c")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "┌──┐\n│a │\n├──┤\n│b…│\n└──┘\n" {
+		t.Errorf("unexpected sections output:\n%s", out)
+	}
+}
+
+func TestRenderSizeErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		box  *Box
+		want string
+	}{
+		{"negative width", NewBox().Width(-1), "width cannot be negative"},
+		{"negative height", NewBox().MaxHeight(-1), "height cannot be negative"},
+		{"min over max width", NewBox().MinWidth(10).MaxWidth(5), "minimum width 10 exceeds maximum width 5"},
+		{"min over max height", NewBox().MinHeight(10).MaxHeight(5), "minimum height 10 exceeds maximum height 5"},
+		{"invalid overflow", NewBox().Overflow(OverflowType("Scroll")), "invalid Overflow Scroll"},
+		{"too narrow", NewBox().Padding(2, 0).Width(6), "width 6 leaves no room for content"},
+		{"too short", NewBox().Padding(0, 1).Height(4), "height 4 leaves no room for content"},
+		{"width overflow", NewBox().Width(6).Overflow(OverflowError), "content width 9 exceeds the maximum of 6"},
+		{"height overflow", NewBox().Height(3).Overflow(OverflowError), "content height 6 exceeds the maximum of 3"},
+		{"labels", NewBox().Width(8).TitlePosition(Top), "border labels need 7 cells but only 6 are available"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.box.Render("Title", "content\nmore")
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.want)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestRenderVerticalAlign(t *testing.T) {
+	cases := []struct {
+		align VerticalAlignType
+		want  string
+	}{
+		{AlignTop, "┌────────────┐\n│  42 req/s  │\n│            │\n│            │\n│            │\n└────────────┘\n"},
+		{AlignMiddle, "┌────────────┐\n│            │\n│  42 req/s  │\n│            │\n│            │\n└────────────┘\n"},
+		{AlignBottom, "┌────────────┐\n│            │\n│            │\n│            │\n│  42 req/s  │\n└────────────┘\n"},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.align), func(t *testing.T) {
+			out, err := NewBox().Padding(2, 0).Height(6).VerticalAlign(tc.align).Render("", "42 req/s")
+			if err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			if out != tc.want {
+				t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, tc.want)
+			}
+		})
+	}
+
+	// Vertical padding stays at the edges, outside the extra lines.
+	out, err := NewBox().Padding(0, 1).Height(8).VerticalAlign(AlignBottom).Color(Red).
+		ColorProfile(colorprofile.TrueColor).Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(ansi.Strip(out), "\n")
+	if lines[5] != "│x│" || lines[6] != "│ │" {
+		t.Errorf("expected content above the bottom padding, got:\n%s", ansi.Strip(out))
+	}
+
+	if _, err := NewBox().VerticalAlign(VerticalAlignType("Centre")).Render("", "x"); err == nil ||
+		!strings.Contains(err.Error(), "invalid Vertical Alignment Centre") {
+		t.Errorf("expected invalid Vertical Alignment error, got %v", err)
+	}
+}
+
+func TestRenderPaddingPerSide(t *testing.T) {
+	out, err := NewBox().PaddingTop(1).PaddingRight(3).PaddingLeft(1).Render("", "hi\nthere")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"┌─────────┐\n" +
+		"│         │\n" +
+		"│ hi      │\n" +
+		"│ there   │\n" +
+		"└─────────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Asymmetric margins also apply to centered and right-aligned content
+	// and to the padding around section dividers.
+	out, err = NewBox().PaddingLeft(2).PaddingBottom(1).ContentAlign(Right).RenderSections("", "a", "bcd")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want = "" +
+		"┌─────┐\n" +
+		"│    a│\n" +
+		"│     │\n" +
+		"├─────┤\n" +
+		"│  bcd│\n" +
+		"│     │\n" +
+		"└─────┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = NewBox().PaddingRight(2).ContentAlign(Center).Render("", "a\nbcd")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want = "┌─────┐\n│ a   │\n│bcd  │\n└─────┘\n"; out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRenderMargin(t *testing.T) {
+	out, err := NewBox().Margin(1, 2, 1, 4).Render("", "hi")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"          \n" +
+		"    ┌──┐  \n" +
+		"    │hi│  \n" +
+		"    └──┘  \n" +
+		"          \n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", out, want)
+	}
+
+	tbl := NewTable().Row("a", "b")
+	out, err = NewBox().Margin(0, 0, 0, 2).RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	if want = "  ┌─┬─┐\n  │a│b│\n  └─┴─┘\n"; out != want {
+		t.Errorf("unexpected table output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	for _, b := range []*Box{NewBox().Margin(0, -1, 0, 0), NewBox().PaddingTop(-1), NewBox().PaddingLeft(-1)} {
+		if _, err := b.Render("", "x"); err == nil {
+			t.Errorf("expected error for negative spacing")
+		}
+	}
+}
+
+func TestRenderBorders(t *testing.T) {
+	cases := []struct {
+		name    string
+		box     *Box
+		content []string
+		want    string
+	}{
+		{
+			name:    "left rule only",
+			box:     NewBox().PaddingLeft(1).Borders(false, false, false, true),
+			content: []string{"quoted\ntext"},
+			want:    "│ quoted\n│ text  \n",
+		},
+		{
+			name:    "top and bottom rules",
+			box:     NewBox().Padding(1, 0).Borders(true, false, true, false),
+			content: []string{"banner"},
+			want:    "────────\n banner \n────────\n",
+		},
+		{
+			name:    "no bottom for stacking",
+			box:     NewBox().Borders(true, true, false, true),
+			content: []string{"a", "b"},
+			want:    "┌─┐\n│a│\n├─┤\n│b│\n",
+		},
+		{
+			name:    "right wall drops its corners",
+			box:     NewBox().Borders(true, false, true, true).ContentAlign(Right),
+			content: []string{"ab\nc"},
+			want:    "┌──\n│ab\n│ c\n└──\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tc.box.RenderSections("", tc.content...)
+			if err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			if out != tc.want {
+				t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", out, tc.want)
+			}
+		})
+	}
+
+	// Hidden edges take no space when sizing the box.
+	out, err := NewBox().Borders(false, false, false, true).Width(5).Height(2).Render("", "abcdefgh")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "│abcd\n│efgh\n" {
+		t.Errorf("unexpected sized output:\n%q", out)
+	}
+
+	// No escape sequences are emitted for hidden walls.
+	out, err = NewBox().Borders(false, false, false, true).Color(Red).ColorProfile(colorprofile.TrueColor).Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want := "\x1b[38;2;128;0;0m│\x1b[mx\n"; out != want {
+		t.Errorf("expected only the left wall colored, got %q", out)
+	}
+
+	_, err = NewBox().Borders(false, true, true, true).TitlePosition(Top).Render("Title", "x")
+	if err == nil || !strings.Contains(err.Error(), "cannot place labels on the hidden top border") {
+		t.Errorf("expected error for a title on a hidden border, got %v", err)
+	}
+}
+
+func TestRenderDistinctEdges(t *testing.T) {
+	halfBlock := NewBox().TopLeft("▛").TopRight("▜").BottomLeft("▙").BottomRight("▟").
+		TopEdge("▀").BottomEdge("▄").LeftEdge("▌").RightEdge("▐")
+	out, err := halfBlock.Render("", "hi")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want := "▛▀▀▜\n▌hi▐\n▙▄▄▟\n"; out != want {
+		t.Errorf("unexpected half-block output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	ascii := NewBox().Style(Classic).TopLeft("/").TopRight("\\").BottomLeft("\\").BottomRight("/").
+		TopEdge("‾").BottomEdge("_")
+	out, err = ascii.Padding(1, 0).TitlePosition(Top).Render("Hi", "there")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	want := "" +
+		"/ Hi ‾‾‾\\\n" +
+		"| there |\n" +
+		"\\_______/\n"
+	if out != want {
+		t.Errorf("unexpected ascii output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Section dividers meet the walls of their own side.
+	out, err = NewBox().LeftEdge("┃").RightEdge("│").LeftTee("").RightTee("").RenderSections("", "a", "b")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want = "┌─┐\n┃a│\n┃─│\n┃b│\n└─┘\n"; out != want {
+		t.Errorf("unexpected sections output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// The inner width fits whole glyphs of both edges.
+	out, err = NewBox().TopEdge("══").BottomEdge("───").Render("", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want = "┌══════┐\n│x     │\n└──────┘\n"; out != want {
+		t.Errorf("unexpected output for wide edges:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Style resets edges to the preset.
+	out, _ = NewBox().TopEdge("▀").Style(Single).Render("", "x")
+	if out != "┌─┐\n│x│\n└─┘\n" {
+		t.Errorf("expected Style to reset the edges, got:\n%s", out)
+	}
+}
+
+func TestRenderGradient(t *testing.T) {
+	b := NewBox().Color(Red).ColorGradient("#ff0000", "#0000ff").ColorProfile(colorprofile.TrueColor)
+	out, err := b.Render("", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	plain, _ := NewBox().Render("", "content")
+	if ansi.Strip(out) != plain {
+		t.Fatalf("expected the gradient not to change the layout, got:\n%s\nwant:\n%s", ansi.Strip(out), plain)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if !strings.HasPrefix(lines[0], "\x1b[38;2;255;0;0m┌") {
+		t.Errorf("expected the top-left corner in the first stop, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[0], "\x1b[38;2;0;0;255m┐\x1b[m") {
+		t.Errorf("expected the top-right corner in the last stop, got %q", lines[0])
+	}
+	if strings.Contains(out, "\x1b[31m") {
+		t.Errorf("expected the gradient to take precedence over Color, got %q", out)
+	}
+	if want := "\x1b[38;2;255;0;0m│\x1b[mcontent\x1b[38;2;0;0;255m│\x1b[m"; lines[1] != want {
+		t.Errorf("expected the walls colored and the content uncolored:\ngot:  %q\nwant: %q", lines[1], want)
+	}
+}
+
+func TestRenderGradientDirections(t *testing.T) {
+	// A vertical gradient colors every glyph of a row alike.
+	out, err := NewBox().ColorGradient("#ff0000", "#0000ff").GradientDirection(GradientVertical).
+		ColorProfile(colorprofile.TrueColor).Render("", "a\nb\nc")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if want := "\x1b[38;2;255;0;0m┌─┐\x1b[m"; lines[0] != want {
+		t.Errorf("unexpected top bar:\ngot:  %q\nwant: %q", lines[0], want)
+	}
+	if want := "\x1b[38;2;0;0;255m└─┘\x1b[m"; lines[len(lines)-1] != want {
+		t.Errorf("unexpected bottom bar:\ngot:  %q\nwant: %q", lines[len(lines)-1], want)
+	}
+
+	// A perimeter gradient returns to the first stop at the top-left corner
+	// and reaches the last stop half way around, at the bottom-right corner.
+	out, err = NewBox().ColorGradient("#ff0000", "#0000ff", "#ff0000").GradientDirection(GradientPerimeter).
+		ColorProfile(colorprofile.TrueColor).Render("", "a")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	lines = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if !strings.HasPrefix(lines[0], "\x1b[38;2;255;0;0m┌") {
+		t.Errorf("expected the top-left corner in the first stop, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[len(lines)-1], "\x1b[38;2;0;0;255m┘\x1b[m") {
+		t.Errorf("expected the bottom-right corner in the middle stop, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestRenderGradientProfiles(t *testing.T) {
+	b := NewBox().ColorGradient("#ff0000", "#0000ff").TopLabel(Center, "\x1b[32mlabel\x1b[0m")
+	out, err := b.ColorProfile(colorprofile.ANSI).Render("", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if strings.Contains(out, "38;2;") || !strings.Contains(out, "\x1b[91m") {
+		t.Errorf("expected the gradient degraded to 16 colors, got %q", out)
+	}
+	if !strings.Contains(out, "\x1b[32mlabel") {
+		t.Errorf("expected the label to keep its own color, got %q", out)
+	}
+
+	out, err = b.ColorProfile(colorprofile.Ascii).Render("", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "┌ \x1b[32mlabel\x1b[0m ┐\n│content│\n└───────┘\n" {
+		t.Errorf("expected no gradient for the Ascii profile, got %q", out)
+	}
+}
+
+func TestRenderTextGradient(t *testing.T) {
+	fg := func(rgb, s string) string { return "\x1b[38;2;" + rgb + "m" + s + "\x1b[m" }
+	red, purple, blue := "255;0;0", "128;0;128", "0;0;255"
+	linear := TextGradient{Stops: []string{"#ff0000", "#0000ff"}}
+
+	cases := []struct {
+		name    string
+		g       TextGradient
+		content string
+		want    []string // Inner text of the content lines.
+	}{
+		{"columns", linear, "abc\nab", []string{fg(red, "a") + fg(purple, "b") + fg(blue, "c"), fg(red, "a") + fg(purple, "b") + " "}},
+		{"wide characters", linear, "盒子", []string{fg(red, "盒") + fg(blue, "子")}},
+		{"styled text", linear, "a\x1b[32mb\x1b[0mc", []string{fg(red, "a") + "\x1b[32mb\x1b[0m" + fg(blue, "c")}},
+		{"spaces", linear, "a bc", []string{fg(red, "a") + " " + fg("85;0;170", "b") + fg(blue, "c")}},
+		{"skip spaces", TextGradient{Stops: linear.Stops, SkipSpaces: true}, "a bc", []string{fg(red, "a") + " " + fg(purple, "b") + fg(blue, "c")}},
+	}
+	for _, tc := range cases {
+		out, err := NewBox().Padding(0, 0).ContentColor(Green).ContentGradient(tc.g).
+			ColorProfile(colorprofile.TrueColor).Render("", tc.content)
+		if err != nil {
+			t.Fatalf("%s: Render returned error: %v", tc.name, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		for i, want := range tc.want {
+			if want = "│" + want + "│"; lines[i+1] != want {
+				t.Errorf("%s: unexpected line %d:\ngot:  %q\nwant: %q", tc.name, i, lines[i+1], want)
+			}
+		}
+	}
+}
+
+func TestRenderTextGradientRainbow(t *testing.T) {
+	at := func(x float64) string {
+		r, g, b, _ := rainbow(x).RGBA()
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r>>8, g>>8, b>>8)
+	}
+	out, err := NewBox().TitleColor(Red).TitleGradient(TextGradient{Frequency: 0.5, Seed: 2}).
+		ColorProfile(colorprofile.TrueColor).Render("ab\nc", "x")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	// The hue advances along the line and from one line to the next.
+	if want := at(1) + "a\x1b[m" + at(1.5) + "b\x1b[m"; !strings.Contains(out, want) {
+		t.Errorf("expected the first title line %q in:\n%q", want, out)
+	}
+	if want := at(1.5) + "c\x1b[m"; !strings.Contains(out, want) {
+		t.Errorf("expected the second title line %q in:\n%q", want, out)
+	}
+	if strings.Contains(out, "\x1b[31m") {
+		t.Errorf("expected the gradient to take precedence over TitleColor, got %q", out)
+	}
+
+	out, err = NewBox().TitleGradient(TextGradient{}).ContentGradient(TextGradient{}).
+		ColorProfile(colorprofile.Ascii).Render("Title", "content")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no color for the Ascii profile, got %q", out)
+	}
+}
+
+func TestRenderPartColors(t *testing.T) {
+	b := NewBox().Padding(1, 0).Color(Cyan).CornerColor(BrightWhite).TopEdgeColor(Green).BottomEdgeColor(Yellow).
+		LeftEdgeColor(Red).ColorProfile(colorprofile.ANSI)
+	out, err := b.RenderSections("", "a", "b")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	plain, _ := NewBox().Padding(1, 0).RenderSections("", "a", "b")
+	if ansi.Strip(out) != plain {
+		t.Fatalf("expected part colors not to change the layout, got:\n%s\nwant:\n%s", ansi.Strip(out), plain)
+	}
+	// Parts without a color of their own, such as the right wall and the
+	// divider, keep Color.
+	want := "" +
+		"\x1b[97m┌\x1b[m\x1b[32m───\x1b[m\x1b[97m┐\x1b[m\n" +
+		"\x1b[31m│\x1b[m a \x1b[36m│\x1b[m\n" +
+		"\x1b[31m├\x1b[m\x1b[36m───┤\x1b[m\n" +
+		"\x1b[31m│\x1b[m b \x1b[36m│\x1b[m\n" +
+		"\x1b[97m└\x1b[m\x1b[33m───\x1b[m\x1b[97m┘\x1b[m\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", out, want)
+	}
+
+	// Part colors override a gradient too.
+	out, err = NewBox().ColorGradient("#ff0000", "#0000ff").RightEdgeColor("#00ff00").
+		ColorProfile(colorprofile.TrueColor).Render("", "a")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want := "\x1b[38;2;255;0;0m│\x1b[ma\x1b[38;2;0;255;0m│\x1b[m"; !strings.Contains(out, want) {
+		t.Errorf("expected the right wall in its own color, got %q", out)
+	}
+}
+
+func TestRenderPartColorsHiddenWall(t *testing.T) {
+	// Without the left wall the top bar starts with an edge, not a corner.
+	out, err := NewBox().Borders(true, true, true, false).CornerColor(Red).TopEdgeColor(Green).
+		ColorProfile(colorprofile.ANSI).Render("", "ab")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if want := "\x1b[32m──\x1b[m\x1b[31m┐\x1b[m"; !strings.HasPrefix(out, want) {
+		t.Errorf("unexpected top bar in %q, want prefix %q", out, want)
+	}
+}
+
+func TestRenderLabelSpaceBackground(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		box  *Box
+		want string
+	}{
+		{"plain", NewBox(), "┌\x1b[44m \x1b[mT\x1b[44m \x1b[m┐"},
+		{"colored title", NewBox().Color(Cyan).TitleColor(Yellow), "\x1b[36m┌\x1b[m\x1b[44m \x1b[m\x1b[33mT\x1b[m\x1b[44m \x1b[m\x1b[36m┐\x1b[m"},
+		{"part colors", NewBox().CornerColor(Red), "\x1b[31m┌\x1b[m\x1b[44m \x1b[mT\x1b[44m \x1b[m\x1b[31m┐\x1b[m"},
+	} {
+		out, err := tc.box.TitlePosition(Top).LabelSpaceBackground(Blue).ColorProfile(colorprofile.ANSI).Render("T", "a")
+		if err != nil {
+			t.Fatalf("%s: Render returned error: %v", tc.name, err)
+		}
+		if top := strings.Split(out, "\n")[0]; top != tc.want {
+			t.Errorf("%s: unexpected top bar:\ngot:  %q\nwant: %q", tc.name, top, tc.want)
+		}
+	}
+}
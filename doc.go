@@ -44,9 +44,62 @@
 //	box.Classic
 //	box.Hidden
 //	box.Block
+//	box.Dashed, box.DashedBold, box.Dotted, box.DottedBold
+//	box.RoundBold
+//	box.InnerHalfBlock, box.OuterHalfBlock
+//	box.Markdown, box.RST, box.Ascii
+//
+// Styles lists the names of all available styles. RegisterStyle adds a
+// custom preset described by StyleGlyphs that Style can then select by name,
+// and LookupStyle returns the glyphs of any style:
+//
+//	err := box.RegisterStyle("House", box.StyleGlyphs{TopLeft: "╒", TopRight: "╕",
+//		BottomLeft: "╘", BottomRight: "╛", Horizontal: "═", Vertical: "│"})
+//	b := box.NewBox().Style("House")
+//
+// ParseBoxStyle, ParseAlignType, and ParseTitlePosition parse names from user
+// input, ignoring case, and return a *ParseError listing the valid options
+// for unknown names. BoxStyle, AlignType, and TitlePosition implement
+// flag.Value and encoding.TextMarshaler/TextUnmarshaler:
+//
+//	style := box.Single
+//	flag.Var(&style, "style", "box style")
 //
 // You can further customize any style by overriding the corner and edge glyphs
 // using TopRight, TopLeft, BottomRight, BottomLeft, Horizontal, and Vertical.
+// TopEdge, BottomEdge, LeftEdge, and RightEdge give a single edge its own
+// glyph, such as ▀ ▄ ▌ ▐ for half-block frames.
+// The junction glyphs used where inner separators meet the border (┬ ┴ ├ ┤ ┼
+// for Single, ╦ ╩ ╠ ╣ ╬ for Double, etc.) are set with TopTee, BottomTee,
+// LeftTee, RightTee, and Cross, and the line of inner dividers with Divider.
+//
+// Borders selects which edges are drawn, e.g. a left rule only for a
+// blockquote, or top and bottom rules around a banner:
+//
+//	b.Borders(false, false, false, true) // top, right, bottom, left
+//
+// # Sections
+//
+// RenderSections splits the content into sections separated by full-width
+// dividers that join the side walls (├────┤ for Single, ╠════╣ for Double,
+// +----+ for Classic):
+//
+//	out, err := b.RenderSections("Report", header, body, footer)
+//
+// # Tables
+//
+// RenderTable draws a Table inside the box. Column and row separators use the
+// junction glyphs of the active style so they connect to the border:
+//
+//	t := box.NewTable().
+//		Header("Name", "Value").
+//		Row("cpu", "42%").
+//		ColumnAlign(box.Left, box.Right)
+//	out, err := box.NewBox().Style(box.Double).Padding(1, 0).RenderTable("Stats", t)
+//
+// Tables size themselves to their cells; RenderTable returns an error when
+// Width, Height or another size option is set, and ErrEmptyTable for a table
+// without columns.
 //
 // # Titles and alignment
 //
@@ -57,6 +110,22 @@
 //	box.Top
 //	box.Bottom
 //
+// TitleAlign places the title on the left (default), in the center, or on
+// the right of the border; Inside titles are centered unless TitleAlign is
+// set.
+//
+// Footer adds a secondary title on the bottom border, with its own
+// FooterColor and FooterAlign, while the main title stays on top or inside:
+//
+//	b.TitlePosition(box.Top).Footer("3 warnings").FooterAlign(box.Right)
+//
+// TopLabel and BottomLabel add labels to the Left, Center and Right slots of
+// a border, colored with LabelColor. A title on the border and the footer
+// occupy the slot matching their alignment:
+//
+//	b.TitlePosition(box.Top).TopLabel(box.Center, "12/40").TopLabel(box.Right, "[main]")
+//	// ┌ Files ──── 12/40 ─── [main] ┐
+//
 // Content alignment is controlled with ContentAlign and the AlignType
 // constants:
 //
@@ -70,6 +139,37 @@
 // default, when wrapping is enabled, the box width is based on two‑thirds of
 // the terminal width. WrapLimit can be used to set an explicit maximum width.
 //
+// # Spacing
+//
+// Padding, HPadding and VPadding set the space between the border and the
+// content; PaddingTop, PaddingRight, PaddingBottom and PaddingLeft set one
+// side each. Margin adds whitespace outside the border, such as an
+// indentation on the left:
+//
+//	b.PaddingLeft(2).PaddingRight(1).Margin(0, 0, 1, 4)
+//
+// # Size
+//
+// Width and Height fix the total size of the box, borders included, and
+// MinWidth, MaxWidth, MinHeight and MaxHeight bound it. Smaller content is
+// padded; larger content is wrapped, truncated with the Ellipsis, or
+// rejected depending on Overflow:
+//
+//	b.Width(30).Height(8).Overflow(box.OverflowTruncate)
+//
+// VerticalAlign places content that is shorter than the box at the top
+// (default), middle, or bottom.
+//
+// # Nesting
+//
+// The output of one box can be used as content of another. Mark it with
+// Rigid (or render it with RenderRigid) so the outer box treats it as an
+// unbreakable rectangle: wrapping never splits its lines, alignment moves it
+// as a whole and the outer box grows to fit it:
+//
+//	inner, err := box.NewBox().RenderRigid("Inner", "nested")
+//	out, err := box.NewBox().WrapLimit(30).Render("Outer", "text\n"+inner)
+//
 // # Colors
 //
 // TitleColor, ContentColor, and Color accept either one of the first 16 ANSI
@@ -77,6 +177,69 @@
 // #RGB / #RRGGBB / rgb:RRRR/GGGG/BBBB / rgba:RRRR/GGGG/BBBB/AAAA value.
 // Invalid colors cause Render to return an error.
 //
+// Background, TitleBackground, and ContentBackground accept the same values
+// and set background colors. Background covers the whole box, border and
+// inner area alike; ContentBackground fills the content, padding, and
+// alignment spaces and defaults to Background; TitleBackground defaults to
+// ContentBackground. Backgrounds are restored after ANSI resets embedded in
+// the text.
+//
+// ColorGradient colors the border with a gradient between two or more
+// stops and overrides Color. GradientDirection makes it fade horizontally,
+// vertically, or clockwise around the perimeter; the colors are converted to
+// the active color profile:
+//
+//	b.ColorGradient("#ff0080", "#00d4ff").GradientDirection(box.GradientPerimeter)
+//
+// CornerColor, TopEdgeColor, BottomEdgeColor, LeftEdgeColor, and
+// RightEdgeColor color parts of the border on their own, overriding Color
+// and ColorGradient, e.g. for a dim frame with bright corners.
+// LabelSpaceBackground sets the background of the spaces framing the title
+// and labels on the bars.
+//
+// TitleGradient and ContentGradient color text character by character,
+// fading between the Stops of a TextGradient or as a rainbow. Text with its
+// own ANSI styling keeps it:
+//
+//	b.TitleGradient(box.TextGradient{Frequency: 0.3, SkipSpaces: true})
+//
+// TitleStyle and ContentStyle set text attributes such as
+// box.AttrBold|box.AttrUnderline, which compose with the colors and are
+// dropped for the colorprofile.Ascii profile:
+//
+//	b.TitleStyle(box.AttrBold | box.AttrUnderline).TitleColor(box.Yellow)
+//
+// # Output
+//
+// Render formats the box for os.Stdout: the default wrap width is taken from
+// its terminal size and colors are degraded to the color profile detected on
+// it, keeping full colors when os.Stdout is redirected. RenderTo writes the
+// box to any io.Writer and detects the profile on that writer instead, so
+// files, buffers and other non-terminal writers receive plain text.
+// RenderSectionsTo and RenderTableTo do the same for sections and tables:
+//
+//	if err := b.RenderTo(os.Stderr, "Warning", "Disk almost full"); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// ColorProfile overrides detection with an explicit colorprofile.Profile,
+// e.g. colorprofile.ANSI256 for log files viewed in a 256-color terminal or
+// colorprofile.Ascii for plain output.
+//
+// # Layout
+//
+// JoinHorizontal places rendered boxes side by side and JoinVertical stacks
+// them. Both measure visible widths, so colors and wide runes are preserved:
+//
+//	row, err := box.JoinHorizontal(2, box.AlignMiddle, before, after)
+//	page, err := box.JoinVertical(box.Center, header, row)
+//
+// Grid flows many boxes into as many columns as fit the terminal (or an
+// explicit Width), with configurable gaps and optionally uniform cells.
+// Outputs whose width cannot be detected get a single column:
+//
+//	out, err := box.NewGrid().Gap(2, 1).UniformCells(true).Render(cells...)
+//
 // # Errors
 //
 // Render returns an error if the style or title position is invalid, the wrap
@@ -85,14 +248,32 @@
 // cannot be determined. MustRender is a convenience wrapper that panics on
 // error.
 //
+// All configuration problems are reported at once, joined with errors.Join.
+// Each is a *ConfigError naming the offending option and value, and wraps a
+// sentinel such as ErrInvalidStyle, ErrNegativeValue or ErrInvalidColor:
+//
+//	_, err := b.Render(title, content)
+//	if errors.Is(err, box.ErrInvalidColor) { ... }
+//	var cerr *box.ConfigError
+//	if errors.As(err, &cerr) {
+//		fmt.Println(cerr.Field, cerr.Value)
+//	}
+//
+// Validate runs the same configuration checks without rendering, so boxes
+// built from user configuration can be checked at startup.
+//
 // # Copying
 //
-// Copy returns a shallow copy of a Box so you can define a base style and
-// derive variants without mutating the original:
+// Copy returns an independent copy of a Box so you can define a base style
+// and derive variants without mutating the original:
 //
 //	base := box.NewBox().Style(box.Single).Padding(2, 1)
 //	info := base.Copy().Color(box.Green)
 //	warn := base.Copy().Color(box.Yellow)
 //
 // Each Copy can then be customized and rendered independently.
+//
+// Rendering never modifies a Box, so a configured Box can be rendered from
+// many goroutines at once. Setters are not synchronized; finish configuring a
+// Box, or take a Copy, before sharing it.
 package box
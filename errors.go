@@ -0,0 +1,74 @@
+package box
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors classifying the problems reported by Render and the other
+// rendering methods. Match them with errors.Is:
+//
+//	if errors.Is(err, box.ErrInvalidColor) { ... }
+var (
+	// ErrInvalidStyle reports an unknown BoxStyle.
+	ErrInvalidStyle = errors.New("invalid style")
+	// ErrInvalidAlignment reports an unknown AlignType, VerticalAlignType or
+	// label slot.
+	ErrInvalidAlignment = errors.New("invalid alignment")
+	// ErrInvalidTitlePosition reports an unknown TitlePosition.
+	ErrInvalidTitlePosition = errors.New("invalid title position")
+	// ErrInvalidOverflow reports an unknown OverflowType.
+	ErrInvalidOverflow = errors.New("invalid overflow")
+	// ErrInvalidColor reports a color that cannot be parsed.
+	ErrInvalidColor = errors.New("invalid color")
+	// ErrInvalidGradient reports an unknown GradientDirection or other
+	// invalid gradient option.
+	ErrInvalidGradient = errors.New("invalid gradient")
+	// ErrNegativeValue reports a negative padding, margin, size or wrap
+	// limit.
+	ErrNegativeValue = errors.New("negative value")
+	// ErrInvalidSize reports size constraints that contradict each other,
+	// such as a minimum width above the maximum.
+	ErrInvalidSize = errors.New("invalid size")
+	// ErrMultiline reports a multiline title, footer or label where only a
+	// single line fits.
+	ErrMultiline = errors.New("multiline text not supported")
+	// ErrConflict reports options that cannot be combined, such as two
+	// labels in the same slot.
+	ErrConflict = errors.New("conflicting options")
+	// ErrContentOverflow reports content or labels that do not fit the
+	// configured size.
+	ErrContentOverflow = errors.New("content does not fit")
+	// ErrEmptyTable reports a table without any columns.
+	ErrEmptyTable = errors.New("empty table")
+	// ErrNoTerminalWidth reports that wrapping needs the terminal width but
+	// the output is not a terminal.
+	ErrNoTerminalWidth = errors.New("cannot determine terminal width")
+)
+
+// ConfigError describes a problem with one option of a Box. It wraps one of
+// the sentinel errors, so it can be matched with both errors.Is and
+// errors.As:
+//
+//	var cerr *box.ConfigError
+//	if errors.As(err, &cerr) {
+//		fmt.Println(cerr.Field, cerr.Value)
+//	}
+type ConfigError struct {
+	Field  string // Option at fault, named after its setter, e.g. "Padding".
+	Value  any    // The offending value.
+	Reason string // Human-readable description of the problem.
+	Err    error  // Sentinel error classifying the problem.
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string { return e.Reason }
+
+// Unwrap returns the sentinel error classifying the problem.
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// configError returns a *ConfigError whose reason is formatted from format
+// and args.
+func configError(field string, value any, err error, format string, args ...any) *ConfigError {
+	return &ConfigError{Field: field, Value: value, Reason: fmt.Sprintf(format, args...), Err: err}
+}
@@ -0,0 +1,243 @@
+package box
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		b     *Box
+		field string
+		value any
+		is    error
+	}{
+		{"style", NewBox().Style("Nope"), "Style", BoxStyle("Nope"), ErrInvalidStyle},
+		{"title align", NewBox().TitleAlign("Middle"), "TitleAlign", AlignType("Middle"), ErrInvalidAlignment},
+		{"content align", NewBox().ContentAlign("Middle"), "ContentAlign", AlignType("Middle"), ErrInvalidAlignment},
+		{"vertical align", NewBox().VerticalAlign("Centre"), "VerticalAlign", VerticalAlignType("Centre"), ErrInvalidAlignment},
+		{"title position", NewBox().TitlePosition("Side"), "TitlePosition", TitlePosition("Side"), ErrInvalidTitlePosition},
+		{"overflow", NewBox().Overflow("Hide"), "Overflow", OverflowType("Hide"), ErrInvalidOverflow},
+		{"padding", NewBox().PaddingLeft(-2), "HPadding", -2, ErrNegativeValue},
+		{"margin", NewBox().Margin(0, -1, 0, 0), "Margin", -1, ErrNegativeValue},
+		{"wrap limit", NewBox().WrapContent(true).WrapLimit(-1), "WrapLimit", -1, ErrNegativeValue},
+		{"min width", NewBox().MinWidth(10).MaxWidth(5), "MinWidth", 10, ErrInvalidSize},
+		{"color", NewBox().Color("NotAColor"), "Color", "NotAColor", ErrInvalidColor},
+		{"title color", NewBox().TitleColor("#12"), "TitleColor", "#12", ErrInvalidColor},
+		{"footer", NewBox().Footer("a\nb"), "Footer", "a\nb", ErrMultiline},
+		{"label slot", NewBox().TopLabel("Middle", "x"), "TopLabel", AlignType("Middle"), ErrInvalidAlignment},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.b.Render("Title", "content")
+			if !errors.Is(err, tc.is) {
+				t.Fatalf("expected errors.Is(err, %v), got %v", tc.is, err)
+			}
+			var cerr *ConfigError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("expected a *ConfigError, got %T", err)
+			}
+			if cerr.Field != tc.field || cerr.Value != tc.value {
+				t.Errorf("expected field %s with value %v, got %s with %v", tc.field, tc.value, cerr.Field, cerr.Value)
+			}
+		})
+	}
+}
+
+func TestConfigErrorsJoined(t *testing.T) {
+	b := NewBox().Style("Nope").Padding(-1, 0).Color("NotAColor").TitlePosition("Side")
+	_, err := b.Render("Title", "content")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, is := range []error{ErrInvalidStyle, ErrNegativeValue, ErrInvalidColor, ErrInvalidTitlePosition} {
+		if !errors.Is(err, is) {
+			t.Errorf("expected the joined error to match %v, got %v", is, err)
+		}
+	}
+	for _, want := range []string{"invalid Box style Nope", "horizontal padding cannot be negative", "unable to parse color: NotAColor", "invalid TitlePosition Side"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err)
+		}
+	}
+
+	// Tables report the same problems.
+	_, err = b.RenderTable("Title", NewTable().Row("a"))
+	if !errors.Is(err, ErrInvalidStyle) || !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected the table error to match every problem, got %v", err)
+	}
+}
+
+func TestLabelErrorsJoined(t *testing.T) {
+	b := NewBox().Color("NotAColor").TitlePosition(Top).TopLabel(Left, "main").
+		Footer("v1").BottomLabel(Left, "12/40").Borders(true, true, false, true)
+	_, err := b.Render("Title", "content")
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error, got %v", err)
+	}
+	// The bad color, the title and footer slot conflicts and the labels on
+	// the hidden bottom border are all reported together.
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var cerr *ConfigError
+		if !errors.As(e, &cerr) {
+			t.Fatalf("expected every problem to be a *ConfigError, got %T: %v", e, e)
+		}
+		fields = append(fields, cerr.Field)
+	}
+	want := []string{"TitleAlign", "FooterAlign", "Borders", "Color"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("expected problems with %v, got %v (%v)", want, fields, err)
+	}
+	if !errors.Is(err, ErrInvalidColor) || !errors.Is(err, ErrConflict) {
+		t.Errorf("expected the joined error to match ErrInvalidColor and ErrConflict, got %v", err)
+	}
+}
+
+func TestRenderErrorsAreTyped(t *testing.T) {
+	_, err := NewBox().Height(3).Overflow(OverflowError).Render("", "a\nb")
+	var cerr *ConfigError
+	if !errors.Is(err, ErrContentOverflow) || !errors.As(err, &cerr) || cerr.Field != "MaxHeight" {
+		t.Errorf("expected a MaxHeight ErrContentOverflow, got %v", err)
+	}
+
+	_, err = NewBox().TopLabel(Left, "a").Borders(false, true, true, true).Render("", "x")
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	_, err = NewBox().WrapContent(true).Render("", "x")
+	if !errors.Is(err, ErrNoTerminalWidth) {
+		t.Errorf("expected ErrNoTerminalWidth, got %v", err)
+	}
+
+	_, err = NewGrid().Gap(-1, 0).Render("x")
+	if !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue from Grid, got %v", err)
+	}
+}
+
+func TestParseErrorIs(t *testing.T) {
+	if _, err := ParseBoxStyle("nope"); !errors.Is(err, ErrInvalidStyle) {
+		t.Errorf("expected ErrInvalidStyle, got %v", err)
+	}
+	if _, err := ParseAlignType("nope"); !errors.Is(err, ErrInvalidAlignment) {
+		t.Errorf("expected ErrInvalidAlignment, got %v", err)
+	}
+	if _, err := ParseTitlePosition("nope"); !errors.Is(err, ErrInvalidTitlePosition) {
+		t.Errorf("expected ErrInvalidTitlePosition, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := NewBox().Style(Round).Padding(2, 1).Color(Cyan).TitlePosition(Top).Validate(); err != nil {
+		t.Errorf("expected a valid box, got %v", err)
+	}
+
+	// Validate needs no terminal, even when wrapping without a limit.
+	if err := NewBox().WrapContent(true).Validate(); err != nil {
+		t.Errorf("expected a valid box, got %v", err)
+	}
+
+	b := NewBox().Style("Nope").TitlePosition("Side").ContentAlign("Middle").VPadding(-1).WrapContent(true).WrapLimit(-1).ContentColor("NotAColor")
+	err := b.Validate()
+	for _, is := range []error{ErrInvalidStyle, ErrInvalidTitlePosition, ErrInvalidAlignment, ErrNegativeValue, ErrInvalidColor} {
+		if !errors.Is(err, is) {
+			t.Errorf("expected Validate to report %v, got %v", is, err)
+		}
+	}
+	if _, renderErr := b.Render("", "x"); renderErr == nil || renderErr.Error() != err.Error() {
+		t.Errorf("expected Render to report the same problems as Validate:\nValidate: %v\nRender: %v", err, renderErr)
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		b     *Box
+		field string
+		is    error
+	}{
+		{"footer and bottom label", NewBox().Footer("v1").BottomLabel(Left, "main"), "FooterAlign", ErrConflict},
+		{"top label on hidden border", NewBox().TopLabel(Right, "12/40").Borders(false, true, true, true), "Borders", ErrConflict},
+		{"footer on hidden border", NewBox().Footer("v1").Borders(true, true, false, true), "Borders", ErrConflict},
+		{"labels wider than Width", NewBox().Width(10).TopLabel(Left, "a long label"), "MaxWidth", ErrContentOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.b.Validate()
+			var cerr *ConfigError
+			if !errors.As(err, &cerr) || cerr.Field != tt.field || !errors.Is(err, tt.is) {
+				t.Fatalf("expected a %s error for %s, got %v", tt.is, tt.field, err)
+			}
+			if _, renderErr := tt.b.Render("", "x"); renderErr == nil || renderErr.Error() != err.Error() {
+				t.Errorf("expected Render to report the same problems as Validate:\nValidate: %v\nRender: %v", err, renderErr)
+			}
+		})
+	}
+
+	if err := NewBox().Width(14).Footer("v1").TopLabel(Left, "main").Validate(); err != nil {
+		t.Errorf("expected labels that fit to be valid, got %v", err)
+	}
+}
+
+func TestRenderInvalidGradient(t *testing.T) {
+	_, err := NewBox().ColorGradient(Red, "nope").Render("", "x")
+	if !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor for an invalid stop, got %v", err)
+	}
+	err = NewBox().ColorGradient("", "#fff").Validate()
+	var cerr *ConfigError
+	if !errors.As(err, &cerr) || cerr.Field != "ColorGradient" || !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected an empty ColorGradient stop to be rejected, got %v", err)
+	}
+	_, err = NewBox().ColorGradient(Red, Blue).GradientDirection("Diagonal").Render("", "x")
+	if !errors.Is(err, ErrInvalidGradient) || !strings.Contains(err.Error(), "invalid GradientDirection Diagonal") {
+		t.Errorf("expected ErrInvalidGradient for an invalid direction, got %v", err)
+	}
+}
+
+func TestInvalidTextGradient(t *testing.T) {
+	err := NewBox().TitleGradient(TextGradient{Stops: []string{Red, "nope"}}).Validate()
+	var cerr *ConfigError
+	if !errors.As(err, &cerr) || cerr.Field != "TitleGradient" || !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected an invalid TitleGradient color, got %v", err)
+	}
+
+	// Empty stops are rejected rather than skipped.
+	for field, b := range map[string]*Box{
+		"TitleGradient":   NewBox().TitleGradient(TextGradient{Stops: []string{"", Blue}}),
+		"ContentGradient": NewBox().ContentGradient(TextGradient{Stops: []string{Red, ""}}),
+	} {
+		err := b.Validate()
+		if !errors.As(err, &cerr) || cerr.Field != field || !errors.Is(err, ErrInvalidColor) {
+			t.Errorf("expected an empty %s stop to be rejected, got %v", field, err)
+		}
+		if _, renderErr := b.Render("Title", "x"); renderErr == nil || renderErr.Error() != err.Error() {
+			t.Errorf("expected Render to report the same problem as Validate:\nValidate: %v\nRender: %v", err, renderErr)
+		}
+	}
+}
+
+func TestInvalidPartColors(t *testing.T) {
+	err := NewBox().CornerColor("nope").LeftEdgeColor("nope").LabelSpaceBackground("nope").Validate()
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error, got %v", err)
+	}
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var cerr *ConfigError
+		if errors.As(e, &cerr) && errors.Is(e, ErrInvalidColor) {
+			fields = append(fields, cerr.Field)
+		}
+	}
+	if want := []string{"CornerColor", "LeftEdgeColor", "LabelSpaceBackground"}; !slices.Equal(fields, want) {
+		t.Errorf("expected invalid colors in %v, got %v", want, fields)
+	}
+}
@@ -29,4 +29,16 @@ func main() {
 		panic(err)
 	}
 	fmt.Println(out)
+
+	// The same attributes without raw escape sequences, composed with colors.
+	styled := b.Copy().
+		TitleStyle(box.AttrBold | box.AttrUnderline).
+		TitleColor(box.Yellow).
+		ContentStyle(box.AttrItalic)
+
+	out, err = styled.Render("Release notes", "Typed attributes survive \033[1membedded\033[0m resets")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(out)
 }
@@ -20,19 +20,7 @@ func indentBox(s string, spaces int) string {
 }
 
 func main() {
-	styles := []box.BoxStyle{
-		box.Single,
-		box.SingleDouble,
-		box.Double,
-		box.DoubleSingle,
-		box.Bold,
-		box.Round,
-		box.Hidden,
-		box.Classic,
-		box.Block,
-	}
-
-	for _, style := range styles {
+	for _, style := range box.Styles() {
 		b := box.NewBox().
 			Padding(4, 3).
 			Style(style).
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	// A release banner whose border fades around the perimeter.
+	banner := box.NewBox().
+		Style(box.Round).
+		Padding(3, 1).
+		TitlePosition(box.Top).
+		ColorGradient("#ff0080", "#7928ca", "#00d4ff", "#ff0080").
+		GradientDirection(box.GradientPerimeter)
+
+	fmt.Print(banner.MustRender("v3.1.0", "Gradient borders are here"))
+
+	// The same stops, faded from left to right and from top to bottom.
+	for _, dir := range []box.GradientDirection{box.GradientHorizontal, box.GradientVertical} {
+		fmt.Print(banner.Copy().GradientDirection(dir).MustRender(string(dir), "one\ntwo\nthree"))
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	services := []struct {
+		name, status, color string
+	}{
+		{"api", "up · 42 req/s", box.Green},
+		{"auth", "up · 3 req/s", box.Green},
+		{"db", "degraded", box.Yellow},
+		{"cache", "up", box.Green},
+		{"queue", "down", box.Red},
+		{"search", "up · 12 req/s", box.Green},
+		{"mailer", "up", box.Green},
+	}
+
+	base := box.NewBox().Style(box.Round).Padding(1, 0).TitlePosition(box.Top)
+
+	var cells []string
+	for _, s := range services {
+		cells = append(cells, base.Copy().Color(s.color).MustRender(s.name, s.status))
+	}
+
+	out, err := box.NewGrid().
+		Width(60). // omit to use the terminal width
+		Gap(2, 1).
+		UniformCells(true).
+		Render(cells...)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(out)
+}
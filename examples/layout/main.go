@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	base := box.NewBox().Padding(2, 1).Style(box.Round).TitlePosition(box.Top)
+
+	before := base.Copy().Color(box.Red).MustRender("Before", "v2.4.1\n3 failing checks")
+	after := base.Copy().Color(box.Green).Padding(2, 2).MustRender("After", "v3.0.0\nall checks passing")
+
+	row, err := box.JoinHorizontal(2, box.AlignMiddle, before, after)
+	if err != nil {
+		panic(err)
+	}
+
+	footer := base.Copy().Color(box.Cyan).MustRender("", "Upgrade complete")
+	out, err := box.JoinVertical(box.Center, row, footer)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(out)
+}
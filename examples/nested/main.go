@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	inner := box.NewBox().Style(box.Round).Padding(1, 0).Color(box.Cyan).TitlePosition(box.Top)
+	config, err := inner.RenderRigid("config.yaml", "name: box-cli-maker\nversion: 3")
+	if err != nil {
+		panic(err)
+	}
+
+	outer := box.NewBox().
+		Style(box.Double).
+		Padding(2, 1).
+		Color("#8B75FF").
+		ContentAlign(box.Center).
+		TitlePosition(box.Top).
+		WrapLimit(24) // wraps the text, never the nested box
+
+	fmt.Println(outer.MustRender("Deployment", "The following configuration will be applied to every cluster:\n\n"+config))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	// A solid panel: border and inner area share one background.
+	panel := box.NewBox().
+		Style(box.Round).
+		Padding(2, 1).
+		Color(box.BrightWhite).
+		Background("#1e3a5f")
+
+	fmt.Print(panel.MustRender("Deploy", "Build \033[1m#4821\033[0m finished in 2m13s\nAll checks passed"))
+
+	// Distinct title and content backgrounds inside a colored frame.
+	card := panel.Copy().
+		TitleBackground(box.Blue).
+		ContentBackground(box.Black).
+		ContentColor(box.Green)
+
+	fmt.Print(card.MustRender("Status", "api      up\nworker   up\ncron     up"))
+
+	// A dim frame with bright corners and a title pill on the top border.
+	frame := box.NewBox().
+		Style(box.Single).
+		Padding(2, 1).
+		Color(box.BrightBlack).
+		CornerColor(box.BrightWhite).
+		TitlePosition(box.Top).
+		TitleColor(box.BrightWhite).
+		TitleBackground(box.Blue).
+		LabelSpaceBackground(box.Blue)
+
+	fmt.Print(frame.MustRender("Notes", "Corners, edges and walls\ncan each take their own color"))
+}
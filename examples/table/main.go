@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	box "github.com/box-cli-maker/box-cli-maker/v3"
+)
+
+func main() {
+	tbl := box.NewTable().
+		Header("Service", "Replicas", "Status").
+		Row("api", "3/3", "running").
+		Row("worker", "5/6", "degraded").
+		Row("scheduler", "1/1", "running").
+		ColumnAlign(box.Left, box.Right, box.Center)
+
+	for _, style := range []box.BoxStyle{box.Single, box.Double, box.Bold, box.Classic} {
+		b := box.NewBox().
+			Style(style).
+			Padding(1, 0).
+			TitlePosition(box.Top).
+			Color("#8B75FF").
+			TitleColor("#00FFB2")
+
+		fmt.Print(b.MustRenderTable("Deployments", tbl))
+	}
+}
@@ -0,0 +1,253 @@
+package box
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// gradient maps the cells of a rows x cols border to colors interpolated
+// between stops.
+type gradient struct {
+	stops []color.Color
+	dir   GradientDirection
+	rows  int
+	cols  int
+	p     colorprofile.Profile
+}
+
+// borderGradient returns the gradient set with ColorGradient for a border
+// of rows x cols cells, or nil when no gradient is set.
+func (b *Box) borderGradient(rows, cols int, p colorprofile.Profile) (*gradient, error) {
+	if len(b.gradient) == 0 {
+		return nil, nil
+	}
+	stops, err := parseStops(b.gradient)
+	if err != nil {
+		return nil, err
+	}
+	return &gradient{stops: stops, dir: b.gradientDir, rows: rows, cols: cols, p: p}, nil
+}
+
+// parseStops parses gradient stops given in any format accepted by Color.
+func parseStops(stops []string) ([]color.Color, error) {
+	out := make([]color.Color, len(stops))
+	for i, s := range stops {
+		c, err := parseColorString(s)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+// at returns the color of the cell at row, col, converted to the profile.
+func (g *gradient) at(row, col int) color.Color {
+	var c color.Color
+	switch g.dir {
+	case GradientVertical:
+		c = lerpStops(g.stops, fraction(row, g.rows-1))
+	case GradientPerimeter:
+		c = g.perimeterAt(row, col)
+	default:
+		c = lerpStops(g.stops, fraction(col, g.cols-1))
+	}
+	return g.p.Convert(c)
+}
+
+// perimeterAt returns the unconverted perimeter color at row, col. Cells
+// inside the border, such as section dividers, blend the colors of the walls
+// on their row.
+func (g *gradient) perimeterAt(row, col int) color.Color {
+	w, h := max(g.cols-1, 0), max(g.rows-1, 0)
+	length := 2 * (w + h)
+	pos := func(row, col int) color.Color {
+		var d int
+		switch {
+		case row == 0:
+			d = col
+		case col >= w:
+			d = w + row
+		case row == h:
+			d = w + h + (w - col)
+		default:
+			d = 2*w + h + (h - row)
+		}
+		return lerpStops(g.stops, fraction(d, length))
+	}
+	if row == 0 || row == h || col == 0 || col >= w {
+		return pos(row, col)
+	}
+	return lerpColor(pos(row, 0), pos(row, w), fraction(col, w))
+}
+
+// paintGraphemes colors the grapheme clusters of s one by one with the color
+// returned by at, which is called for every cluster in order. Clusters for
+// which at returns nil and text carrying its own styling are left as is.
+func paintGraphemes(s string, at func(cluster string) color.Color) string {
+	var sb, run strings.Builder
+	var runColor color.Color
+	flush := func() {
+		sb.WriteString(applyConvertedColor(run.String(), runColor))
+		run.Reset()
+	}
+
+	styled := false
+	var state byte
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+		if width == 0 {
+			flush()
+			sb.WriteString(seq)
+			if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+				styled = seq != "\x1b[m" && seq != "\x1b[0m"
+			}
+			continue
+		}
+		c := at(seq)
+		if styled || c == nil {
+			flush()
+			sb.WriteString(seq)
+			continue
+		}
+		if c != runColor {
+			flush()
+			runColor = c
+		}
+		run.WriteString(seq)
+	}
+	flush()
+	return sb.String()
+}
+
+// TextGradient describes the per-character coloring set with TitleGradient
+// and ContentGradient. With Stops the text fades linearly between them from
+// the first character of its longest line to the last; without Stops it is
+// colored as a rainbow. Characters are grapheme clusters.
+type TextGradient struct {
+	// Stops are the colors of a linear gradient, in any format accepted by
+	// Color.
+	Stops []string
+	// Frequency sets how fast the rainbow cycles through the hues, per
+	// character; 0 means 0.1.
+	Frequency float64
+	// Seed shifts the hue the rainbow starts with, in characters.
+	Seed int
+	// SkipSpaces leaves whitespace out of the gradient, so the colors only
+	// advance over visible characters. Whitespace is never colored itself.
+	SkipSpaces bool
+}
+
+// stops returns the gradient's stops, or nil for a nil gradient.
+func (g *TextGradient) stops() []string {
+	if g == nil {
+		return nil
+	}
+	return g.Stops
+}
+
+// applyTextColor colors text with the gradient g when set, or with colorStr
+// otherwise. line is the index of the first line of str.
+func applyTextColor(str, colorStr string, g *TextGradient, line int, p colorprofile.Profile) (string, error) {
+	if g != nil {
+		return g.paint(str, line, p)
+	}
+	return applyColor(str, colorStr, p)
+}
+
+// defaultRainbowFrequency is the rainbow frequency used when none is set.
+const defaultRainbowFrequency = 0.1
+
+// paint colors the unstyled text of s with the gradient, cluster by cluster.
+// line is the index of the first line of s, which shifts the hue of the
+// rainbow from line to line.
+func (g *TextGradient) paint(s string, line int, p colorprofile.Profile) (string, error) {
+	if g == nil || s == "" {
+		return s, nil
+	}
+	stops, err := parseStops(g.Stops)
+	if err != nil {
+		return "", err
+	}
+	// Positions count grapheme clusters, so a wide character takes a single
+	// step of the gradient.
+	advance := func(cluster string) int {
+		if g.SkipSpaces && strings.TrimSpace(cluster) == "" {
+			return 0
+		}
+		return 1
+	}
+
+	lines := strings.Split(s, "\n")
+	longest := 0
+	for _, l := range lines {
+		n := 0
+		paintGraphemes(l, func(cluster string) color.Color {
+			n += advance(cluster)
+			return nil
+		})
+		longest = max(longest, n)
+	}
+	freq := g.Frequency
+	if freq == 0 {
+		freq = defaultRainbowFrequency
+	}
+
+	for i, l := range lines {
+		col := 0
+		lines[i] = paintGraphemes(l, func(cluster string) color.Color {
+			pos := col
+			col += advance(cluster)
+			if strings.TrimSpace(cluster) == "" {
+				return nil
+			}
+			if len(stops) > 0 {
+				return p.Convert(lerpStops(stops, fraction(pos, longest-1)))
+			}
+			return p.Convert(rainbow(freq * float64(pos+line+i+g.Seed)))
+		})
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// rainbow returns the rainbow color at phase x, cycling every 2π.
+func rainbow(x float64) color.Color {
+	channel := func(shift float64) uint8 {
+		return uint8(math.Sin(x+shift)*127 + 128)
+	}
+	return color.RGBA{R: channel(0), G: channel(2 * math.Pi / 3), B: channel(4 * math.Pi / 3), A: 0xff}
+}
+
+// fraction returns a/b clamped to [0, 1], or 0 when b is not positive.
+func fraction(a, b int) float64 {
+	if b <= 0 {
+		return 0
+	}
+	return min(max(float64(a)/float64(b), 0), 1)
+}
+
+// lerpStops returns the color at t in [0, 1] along evenly spaced stops.
+func lerpStops(stops []color.Color, t float64) color.Color {
+	if len(stops) == 1 {
+		return stops[0]
+	}
+	seg := t * float64(len(stops)-1)
+	i := min(int(seg), len(stops)-2)
+	return lerpColor(stops[i], stops[i+1], seg-float64(i))
+}
+
+// lerpColor blends a and b, returning a at t=0 and b at t=1.
+func lerpColor(a, b color.Color, t float64) color.Color {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	mix := func(x, y uint32) uint8 {
+		return uint8((float64(x>>8)*(1-t) + float64(y>>8)*t) + 0.5)
+	}
+	return color.RGBA{R: mix(ar, br), G: mix(ag, bg), B: mix(ab, bb), A: 0xff}
+}
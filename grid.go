@@ -0,0 +1,215 @@
+package box
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// Grid flows many rendered boxes into rows and columns that fit the
+// available width.
+//
+// Cells are usually rendered from a shared base Box with Copy:
+//
+//	base := box.NewBox().Style(box.Round).Padding(1, 0)
+//	var cells []string
+//	for _, s := range services {
+//		cells = append(cells, base.Copy().Color(s.Color).MustRender(s.Name, s.Status))
+//	}
+//	out, err := box.NewGrid().Gap(2, 1).Render(cells...)
+type Grid struct {
+	width      int               // Target width; 0 means detect from the output.
+	colGap     int               // Spaces between columns.
+	rowGap     int               // Blank lines between rows.
+	uniform    bool              // Whether every cell gets the same width and height.
+	cellAlign  AlignType         // Horizontal alignment of a box inside its cell.
+	cellVAlign VerticalAlignType // Vertical alignment of a box inside its cell.
+}
+
+// NewGrid creates a Grid with a column gap of one space and no row gap.
+func NewGrid() *Grid {
+	return &Grid{colGap: 1}
+}
+
+// Width sets the total width the grid may occupy.
+//
+// When unset, the width of the terminal the grid is rendered to is used.
+func (g *Grid) Width(width int) *Grid {
+	g.width = width
+	return g
+}
+
+// Gap sets the spaces between columns (col) and blank lines between rows (row).
+func (g *Grid) Gap(col, row int) *Grid {
+	g.colGap = col
+	g.rowGap = row
+	return g
+}
+
+// UniformCells makes every cell as wide as the widest box and as tall as the
+// tallest one, so all columns and rows line up. Otherwise each column is as
+// wide as its widest box and each row as tall as its tallest box.
+func (g *Grid) UniformCells(uniform bool) *Grid {
+	g.uniform = uniform
+	return g
+}
+
+// CellAlign sets how a box is placed inside a cell that is larger than it.
+//
+// Defaults are box.Left and box.AlignTop.
+func (g *Grid) CellAlign(align AlignType, valign VerticalAlignType) *Grid {
+	g.cellAlign = align
+	g.cellVAlign = valign
+	return g
+}
+
+// MustRender is like Render but panics if an error occurs.
+func (g *Grid) MustRender(cells ...string) string {
+	s, err := g.Render(cells...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Render lays out the rendered boxes in cells for os.Stdout.
+//
+// The number of columns is the largest that fits the target width given the
+// widest box and the column gap; narrow outputs fall back to a single column.
+//
+// When no Width is set and the terminal width cannot be determined, such as
+// when os.Stdout is redirected, the boxes are stacked in a single column.
+//
+// It returns an error if the width or a gap is negative or an alignment is
+// invalid.
+func (g *Grid) Render(cells ...string) (string, error) {
+	return g.render(os.Stdout, cells)
+}
+
+// RenderTo lays out the rendered boxes and writes them to w, using the
+// terminal width of w when no Width is set, or a single column when w is not
+// a terminal.
+func (g *Grid) RenderTo(w io.Writer, cells ...string) error {
+	s, err := g.render(w, cells)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+func (g *Grid) render(w io.Writer, cells []string) (string, error) {
+	var errs []error
+	if g.width < 0 {
+		errs = append(errs, configError("Width", g.width, ErrNegativeValue, "grid width cannot be negative"))
+	}
+	if g.colGap < 0 || g.rowGap < 0 {
+		errs = append(errs, configError("Gap", min(g.colGap, g.rowGap), ErrNegativeValue, "grid gap cannot be negative"))
+	}
+	if _, _, err := horizOffsets(0, 0, g.cellAlign); err != nil {
+		errs = append(errs, configError("CellAlign", g.cellAlign, ErrInvalidAlignment, "%s", err))
+	}
+	if _, _, err := vertOffsets(0, 0, g.cellVAlign); err != nil {
+		errs = append(errs, configError("CellAlign", g.cellVAlign, ErrInvalidAlignment, "%s", err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+	if len(cells) == 0 {
+		return "", nil
+	}
+
+	// Without a Width, outputs whose width cannot be determined, such as
+	// files and pipes, get a single column.
+	width := g.width
+	if width == 0 {
+		width, _ = termWidth(w)
+	}
+
+	blocks := make([]block, len(cells))
+	widest, tallest := 0, 0
+	for i, s := range cells {
+		blocks[i] = newBlock(s)
+		widest = max(widest, blocks[i].width)
+		tallest = max(tallest, len(blocks[i].lines))
+	}
+
+	// Empty cells without a gap take no space, so they all fit on one row.
+	cols := len(blocks)
+	if widest+g.colGap > 0 {
+		cols = min(max((width+g.colGap)/(widest+g.colGap), 1), cols)
+	}
+
+	colWidths := make([]int, cols)
+	for i, blk := range blocks {
+		if g.uniform {
+			colWidths[i%cols] = widest
+		} else {
+			colWidths[i%cols] = max(colWidths[i%cols], blk.width)
+		}
+	}
+
+	var sb strings.Builder
+	separator := strings.Repeat(" ", g.colGap)
+	for start := 0; start < len(blocks); start += cols {
+		row := blocks[start:min(start+cols, len(blocks))]
+
+		height := tallest
+		if !g.uniform {
+			height = 0
+			for _, blk := range row {
+				height = max(height, len(blk.lines))
+			}
+		}
+
+		if start > 0 {
+			sb.WriteString(strings.Repeat("\n", g.rowGap))
+		}
+
+		lines := make([]strings.Builder, height)
+		for c, blk := range row {
+			cell, err := g.fitCell(blk, colWidths[c], height)
+			if err != nil {
+				return "", err
+			}
+			for r := range lines {
+				if c > 0 {
+					lines[r].WriteString(separator)
+				}
+				lines[r].WriteString(cell[r])
+			}
+		}
+		for r := range lines {
+			sb.WriteString(lines[r].String())
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// fitCell pads blk to exactly width cells and height lines according to the
+// grid's cell alignment.
+func (g *Grid) fitCell(blk block, width, height int) ([]string, error) {
+	left, _, err := horizOffsets(blk.width, width, g.cellAlign)
+	if err != nil {
+		return nil, err
+	}
+	above, _, err := vertOffsets(len(blk.lines), height, g.cellVAlign)
+	if err != nil {
+		return nil, err
+	}
+
+	blank := strings.Repeat(" ", width)
+	indent := strings.Repeat(" ", left)
+	cell := make([]string, height)
+	for r := range cell {
+		idx := r - above
+		if idx < 0 || idx >= len(blk.lines) {
+			cell[r] = blank
+			continue
+		}
+		cell[r] = padLine(indent+padLine(blk.lines[idx], blk.width), width)
+	}
+	return cell, nil
+}
@@ -0,0 +1,123 @@
+package box
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestGridFlowsIntoColumns(t *testing.T) {
+	base := NewBox().Padding(1, 0).Style(Round)
+	var cells []string
+	for _, name := range []string{"api", "db", "cache", "queue", "auth"} {
+		cells = append(cells, base.Copy().Color(Green).MustRender("", name))
+	}
+	cellWidth := runewidth.StringWidth(ansi.Strip(strings.Split(cells[2], "\n")[0]))
+	cellHeight := strings.Count(cells[0], "\n")
+
+	// Room for exactly two columns with a gap of 2.
+	out, err := NewGrid().Width(2*cellWidth+2).Gap(2, 1).UniformCells(true).Render(cells...)
+	if err != nil {
+		t.Fatalf("Grid.Render returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	wantLines := 3*cellHeight + 2 // three rows separated by one blank line
+	if len(lines) != wantLines {
+		t.Fatalf("expected %d lines, got %d:\n%s", wantLines, len(lines), ansi.Strip(out))
+	}
+	if got := runewidth.StringWidth(ansi.Strip(lines[0])); got != 2*cellWidth+2 {
+		t.Errorf("expected first row width %d, got %d", 2*cellWidth+2, got)
+	}
+	if got := strings.Count(ansi.Strip(lines[0]), "╭"); got != 2 {
+		t.Errorf("expected two boxes in the first row, got %d: %q", got, ansi.Strip(lines[0]))
+	}
+	if lines[cellHeight] != "" {
+		t.Errorf("expected blank row gap, got %q", lines[cellHeight])
+	}
+	last := ansi.Strip(lines[len(lines)-1])
+	if got := strings.Count(last, "╰"); got != 1 {
+		t.Errorf("expected a single box in the last row, got %d: %q", got, last)
+	}
+}
+
+func TestGridNarrowFallsBackToSingleColumn(t *testing.T) {
+	cells := []string{"+--+\n|ab|\n+--+\n", "+---+\n|abc|\n+---+\n"}
+
+	out, err := NewGrid().Width(3).Render(cells...)
+	if err != nil {
+		t.Fatalf("Grid.Render returned error: %v", err)
+	}
+	want := "+--+ \n|ab| \n+--+ \n+---+\n|abc|\n+---+\n"
+	if out != want {
+		t.Errorf("expected single column layout %q, got %q", want, out)
+	}
+}
+
+func TestGridCellAlign(t *testing.T) {
+	cells := []string{"a\n", "bbb\nbbb\nbbb\n"}
+
+	out, err := NewGrid().Width(100).Gap(1, 0).UniformCells(true).CellAlign(Center, AlignMiddle).Render(cells...)
+	if err != nil {
+		t.Fatalf("Grid.Render returned error: %v", err)
+	}
+	want := "    bbb\n a  bbb\n    bbb\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestGridNoWidth(t *testing.T) {
+	oldIsTTY := isTTY
+	defer func() { isTTY = oldIsTTY }()
+	isTTY = func(fd uintptr) bool { return false }
+
+	// Without a Width, non-TTY outputs get a single column.
+	out, err := NewGrid().Render("a", "b")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "a\nb\n" {
+		t.Errorf("expected a single column, got %q", out)
+	}
+
+	// Empty cells without a gap used to divide by zero.
+	out, err = NewGrid().Gap(0, 0).Width(10).Render("", "")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if out != "" {
+		t.Errorf("expected empty output, got %q", out)
+	}
+}
+
+func TestGridErrors(t *testing.T) {
+	oldIsTTY := isTTY
+	defer func() { isTTY = oldIsTTY }()
+	isTTY = func(fd uintptr) bool { return false }
+
+	cases := []struct {
+		name string
+		grid *Grid
+		want string
+		is   error
+	}{
+		{"negative width", NewGrid().Width(-1), "grid width cannot be negative", ErrNegativeValue},
+		{"negative gap", NewGrid().Width(10).Gap(-1, 0), "grid gap cannot be negative", ErrNegativeValue},
+		{"invalid align", NewGrid().Width(10).CellAlign(AlignType("Weird"), AlignTop), "invalid Content Alignment", ErrInvalidAlignment},
+		{"invalid vertical align", NewGrid().Width(10).CellAlign(Left, VerticalAlignType("Weird")), "invalid Vertical Alignment", ErrInvalidAlignment},
+	}
+	for _, tc := range cases {
+		var cerr *ConfigError
+		if _, err := tc.grid.Render("x\n"); err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		} else if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: unexpected error message: %v", tc.name, err)
+		} else if !errors.Is(err, tc.is) || !errors.As(err, &cerr) {
+			t.Errorf("%s: expected a *ConfigError matching %v, got %v", tc.name, tc.is, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package box
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// block is a rendered box (or any multi-line string) split into lines along
+// with its visible width.
+type block struct {
+	lines []string // tab-expanded lines
+	width int      // visible width of the widest line
+}
+
+// newBlock splits s into lines, dropping the single trailing newline that
+// Render emits, and measures the visible width of the widest line.
+func newBlock(s string) block {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return block{}
+	}
+	width, expanded := longestLine(strings.Split(s, "\n"))
+	lines := make([]string, len(expanded))
+	for i, l := range expanded {
+		lines[i] = l.line
+	}
+	return block{lines: lines, width: width}
+}
+
+// padLine pads line with spaces on the right up to width visible cells.
+func padLine(line string, width int) string {
+	if w := visibleWidth(line); w < width {
+		return line + strings.Repeat(" ", width-w)
+	}
+	return line
+}
+
+// visibleWidth returns the number of cells s occupies once ANSI sequences
+// are stripped.
+func visibleWidth(s string) int {
+	return runewidth.StringWidth(ansi.Strip(s))
+}
+
+// vertOffsets returns how many blank lines go above and below a block of
+// height lines to fill total lines with the given alignment.
+func vertOffsets(height, total int, align VerticalAlignType) (int, int, error) {
+	diff := max(total-height, 0)
+	switch align {
+	case AlignTop, "":
+		return 0, diff, nil
+	case AlignMiddle:
+		return diff / 2, diff - diff/2, nil
+	case AlignBottom:
+		return diff, 0, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid Vertical Alignment %s", align)
+	}
+}
+
+// horizOffsets returns how many spaces go left and right of a block of
+// width cells to fill total cells with the given alignment.
+func horizOffsets(width, total int, align AlignType) (int, int, error) {
+	diff := max(total-width, 0)
+	switch align {
+	case Left, "":
+		return 0, diff, nil
+	case Center:
+		return diff / 2, diff - diff/2, nil
+	case Right:
+		return diff, 0, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid Content Alignment %s", align)
+	}
+}
+
+// JoinHorizontal places rendered boxes side by side, separated by gap
+// spaces.
+//
+// Boxes shorter than the tallest one are padded with blank lines according
+// to align (box.AlignTop, box.AlignMiddle or box.AlignBottom). Widths are
+// measured on visible cells, so colored boxes and wide runes line up, and
+// existing ANSI styling is preserved. Like Render, the result ends with a
+// newline.
+//
+// It returns an error if gap is negative or align is invalid, as
+// *ConfigError values joined with errors.Join like Render's.
+func JoinHorizontal(gap int, align VerticalAlignType, boxes ...string) (string, error) {
+	var errs []error
+	if gap < 0 {
+		errs = append(errs, configError("Gap", gap, ErrNegativeValue, "gap cannot be negative"))
+	}
+	if _, _, err := vertOffsets(0, 0, align); err != nil {
+		errs = append(errs, configError("Align", align, ErrInvalidAlignment, "%s", err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+	if len(boxes) == 0 {
+		return "", nil
+	}
+
+	blocks := make([]block, len(boxes))
+	height := 0
+	for i, s := range boxes {
+		blocks[i] = newBlock(s)
+		height = max(height, len(blocks[i].lines))
+	}
+
+	rows := make([]strings.Builder, height)
+	separator := strings.Repeat(" ", gap)
+	for i, blk := range blocks {
+		above, _, err := vertOffsets(len(blk.lines), height, align)
+		if err != nil {
+			return "", err
+		}
+		blank := strings.Repeat(" ", blk.width)
+		for r := range rows {
+			if i > 0 {
+				rows[r].WriteString(separator)
+			}
+			idx := r - above
+			if idx < 0 || idx >= len(blk.lines) {
+				rows[r].WriteString(blank)
+				continue
+			}
+			rows[r].WriteString(padLine(blk.lines[idx], blk.width))
+		}
+	}
+
+	var sb strings.Builder
+	for r := range rows {
+		sb.WriteString(rows[r].String())
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// JoinVertical stacks rendered boxes on top of each other.
+//
+// Boxes narrower than the widest one are shifted as a whole according to
+// align (box.Left, box.Center or box.Right) and padded with spaces so the
+// result is rectangular and can itself be passed to JoinHorizontal. Like
+// Render, the result ends with a newline.
+//
+// It returns a *ConfigError wrapping ErrInvalidAlignment if align is
+// invalid.
+func JoinVertical(align AlignType, boxes ...string) (string, error) {
+	if _, _, err := horizOffsets(0, 0, align); err != nil {
+		return "", configError("Align", align, ErrInvalidAlignment, "%s", err)
+	}
+	blocks := make([]block, len(boxes))
+	width := 0
+	for i, s := range boxes {
+		blocks[i] = newBlock(s)
+		width = max(width, blocks[i].width)
+	}
+
+	var sb strings.Builder
+	for _, blk := range blocks {
+		left, _, err := horizOffsets(blk.width, width, align)
+		if err != nil {
+			return "", err
+		}
+		indent := strings.Repeat(" ", left)
+		for _, line := range blk.lines {
+			sb.WriteString(padLine(indent+padLine(line, blk.width), width))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
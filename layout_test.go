@@ -0,0 +1,100 @@
+package box
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestJoinHorizontal(t *testing.T) {
+	before := NewBox().Padding(1, 0).Color(Red).MustRender("Before", "short")
+	after := NewBox().Padding(1, 2).Color(Green).MustRender("After", "盒子製造商 content")
+
+	out, err := JoinHorizontal(2, AlignMiddle, before, after)
+	if err != nil {
+		t.Fatalf("JoinHorizontal returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	afterLines := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	if len(lines) != len(afterLines) {
+		t.Fatalf("expected %d lines (height of tallest box), got %d", len(afterLines), len(lines))
+	}
+
+	width := runewidth.StringWidth(ansi.Strip(lines[0]))
+	for i, l := range lines {
+		if w := runewidth.StringWidth(ansi.Strip(l)); w != width {
+			t.Errorf("line %d: expected visible width %d, got %d: %q", i, width, w, ansi.Strip(l))
+		}
+	}
+
+	// The shorter box is centered, so the first line only holds padding for it.
+	if !strings.HasPrefix(ansi.Strip(lines[0]), " ") {
+		t.Errorf("expected blank area above centered box, got %q", ansi.Strip(lines[0]))
+	}
+	if !strings.Contains(out, "\x1b[") {
+		t.Errorf("expected colors to be preserved, got %q", out)
+	}
+}
+
+func TestJoinHorizontalAlignment(t *testing.T) {
+	tall := "a\nb\nc\n"
+	short := "x\n"
+
+	cases := []struct {
+		align VerticalAlignType
+		want  string
+	}{
+		{AlignTop, "a x\nb  \nc  \n"},
+		{AlignMiddle, "a  \nb x\nc  \n"},
+		{AlignBottom, "a  \nb  \nc x\n"},
+	}
+	for _, tc := range cases {
+		got, err := JoinHorizontal(1, tc.align, tall, short)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.align, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.align, tc.want, got)
+		}
+	}
+
+	var cerr *ConfigError
+	if _, err := JoinHorizontal(-1, AlignTop, tall, short); !errors.Is(err, ErrNegativeValue) || !errors.As(err, &cerr) || cerr.Field != "Gap" {
+		t.Errorf("expected a Gap ErrNegativeValue for negative gap, got %v", err)
+	}
+	if _, err := JoinHorizontal(1, VerticalAlignType("Weird"), tall, short); !errors.Is(err, ErrInvalidAlignment) || !errors.As(err, &cerr) || cerr.Field != "Align" {
+		t.Errorf("expected an Align ErrInvalidAlignment for invalid vertical alignment, got %v", err)
+	}
+}
+
+func TestJoinVertical(t *testing.T) {
+	wide := "abcd\nabcd\n"
+	narrow := "xy\n"
+
+	cases := []struct {
+		align AlignType
+		want  string
+	}{
+		{Left, "abcd\nabcd\nxy  \n"},
+		{Center, "abcd\nabcd\n xy \n"},
+		{Right, "abcd\nabcd\n  xy\n"},
+	}
+	for _, tc := range cases {
+		got, err := JoinVertical(tc.align, wide, narrow)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.align, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.align, tc.want, got)
+		}
+	}
+
+	var cerr *ConfigError
+	if _, err := JoinVertical(AlignType("Weird"), wide, narrow); !errors.Is(err, ErrInvalidAlignment) || !errors.As(err, &cerr) || cerr.Field != "Align" {
+		t.Errorf("expected an Align ErrInvalidAlignment for invalid alignment, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package box
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// rigidMarker prefixes every line of a rigid block. It is an APC sequence,
+// which terminals ignore and ansi.Strip removes, so marked blocks still print
+// correctly on their own.
+const rigidMarker = "\x1b_box-cli-maker:rigid\x1b\\"
+
+// Rigid marks a pre-rendered block, typically the output of another box's
+// Render, as an unbreakable rectangle for use as (part of) the content of an
+// outer box.
+//
+// Lines of a rigid block are never wrapped, they are padded to the width of
+// the widest line so alignment moves the block as a whole, and the outer box
+// grows to fit the block even when it is wider than the wrap limit. The
+// trailing newline emitted by Render is dropped.
+//
+// Example:
+//
+//	inner := box.NewBox().Style(box.Round).MustRender("Inner", "nested content")
+//	outer := box.NewBox().WrapLimit(20).MustRender("Outer", "Some text\n"+box.Rigid(inner))
+func Rigid(block string) string {
+	blk := newBlock(block)
+	var sb strings.Builder
+	for i, line := range blk.lines {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rigidMarker)
+		sb.WriteString(padLine(line, blk.width))
+	}
+	return sb.String()
+}
+
+// RenderRigid renders the box like Render and marks the result with Rigid so
+// it can be nested inside the content of another box.
+func (b *Box) RenderRigid(title, content string) (string, error) {
+	s, err := b.Render(title, content)
+	if err != nil {
+		return "", err
+	}
+	return Rigid(s), nil
+}
+
+// wrapContent wraps every line of content that is not part of a rigid block
+// to limit cells, leaving rigid lines untouched. A limit of 0 disables
+// wrapping. Rigid markers are removed from the result.
+func wrapContent(content string, limit int) string {
+	if !strings.Contains(content, rigidMarker) {
+		if limit > 0 {
+			content = ansi.Wrap(content, limit, "")
+		}
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, rigidMarker); ok {
+			lines[i] = rest
+			continue
+		}
+		if limit > 0 {
+			lines[i] = ansi.Wrap(line, limit, "")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
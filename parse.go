@@ -0,0 +1,121 @@
+package box
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError reports a string that does not name a valid option, such as an
+// unknown BoxStyle passed on the command line.
+type ParseError struct {
+	Type  string   // Name of the type being parsed, e.g. "BoxStyle".
+	Value string   // The rejected input.
+	Valid []string // The accepted options.
+
+	err error // Sentinel error classifying the problem.
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s %q: valid options are %s", e.Type, e.Value, strings.Join(e.Valid, ", "))
+}
+
+// Unwrap returns the sentinel error for the parsed type, such as
+// ErrInvalidStyle for a BoxStyle.
+func (e *ParseError) Unwrap() error { return e.err }
+
+// parseOption returns the option matching s case-insensitively.
+func parseOption[T ~string](typ, s string, sentinel error, options []T) (T, error) {
+	valid := make([]string, len(options))
+	for i, opt := range options {
+		if strings.EqualFold(strings.TrimSpace(s), string(opt)) {
+			return opt, nil
+		}
+		valid[i] = string(opt)
+	}
+	return "", &ParseError{Type: typ, Value: s, Valid: valid, err: sentinel}
+}
+
+// ParseBoxStyle returns the style named by s, ignoring case, e.g. "round"
+// gives box.Round. Styles added with RegisterStyle are accepted too.
+//
+// It returns a *ParseError listing the available styles if s names none of
+// them.
+func ParseBoxStyle(s string) (BoxStyle, error) {
+	return parseOption("BoxStyle", s, ErrInvalidStyle, Styles())
+}
+
+// ParseAlignType returns the alignment named by s, ignoring case, e.g.
+// "center" gives box.Center.
+//
+// It returns a *ParseError listing the valid alignments if s names none of
+// them.
+func ParseAlignType(s string) (AlignType, error) {
+	return parseOption("AlignType", s, ErrInvalidAlignment, []AlignType{Left, Center, Right})
+}
+
+// ParseTitlePosition returns the title position named by s, ignoring case,
+// e.g. "top" gives box.Top.
+//
+// It returns a *ParseError listing the valid positions if s names none of
+// them.
+func ParseTitlePosition(s string) (TitlePosition, error) {
+	return parseOption("TitlePosition", s, ErrInvalidTitlePosition, []TitlePosition{Inside, Top, Bottom})
+}
+
+// String returns the style name.
+func (s BoxStyle) String() string { return string(s) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BoxStyle) MarshalText() ([]byte, error) { return []byte(s), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseBoxStyle.
+func (s *BoxStyle) UnmarshalText(text []byte) error { return s.Set(string(text)) }
+
+// Set implements flag.Value using ParseBoxStyle.
+func (s *BoxStyle) Set(value string) error {
+	style, err := ParseBoxStyle(value)
+	if err != nil {
+		return err
+	}
+	*s = style
+	return nil
+}
+
+// String returns the alignment name.
+func (a AlignType) String() string { return string(a) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (a AlignType) MarshalText() ([]byte, error) { return []byte(a), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseAlignType.
+func (a *AlignType) UnmarshalText(text []byte) error { return a.Set(string(text)) }
+
+// Set implements flag.Value using ParseAlignType.
+func (a *AlignType) Set(value string) error {
+	align, err := ParseAlignType(value)
+	if err != nil {
+		return err
+	}
+	*a = align
+	return nil
+}
+
+// String returns the position name.
+func (p TitlePosition) String() string { return string(p) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (p TitlePosition) MarshalText() ([]byte, error) { return []byte(p), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseTitlePosition.
+func (p *TitlePosition) UnmarshalText(text []byte) error { return p.Set(string(text)) }
+
+// Set implements flag.Value using ParseTitlePosition.
+func (p *TitlePosition) Set(value string) error {
+	pos, err := ParseTitlePosition(value)
+	if err != nil {
+		return err
+	}
+	*p = pos
+	return nil
+}
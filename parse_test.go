@@ -0,0 +1,122 @@
+package box
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		got  func(string) (string, error)
+		want string
+	}{
+		{"round", func(s string) (string, error) { v, err := ParseBoxStyle(s); return string(v), err }, string(Round)},
+		{"SINGLEDOUBLE", func(s string) (string, error) { v, err := ParseBoxStyle(s); return string(v), err }, string(SingleDouble)},
+		{" ascii ", func(s string) (string, error) { v, err := ParseBoxStyle(s); return string(v), err }, string(Ascii)},
+		{"center", func(s string) (string, error) { v, err := ParseAlignType(s); return string(v), err }, string(Center)},
+		{"Right", func(s string) (string, error) { v, err := ParseAlignType(s); return string(v), err }, string(Right)},
+		{"top", func(s string) (string, error) { v, err := ParseTitlePosition(s); return string(v), err }, string(Top)},
+		{"INSIDE", func(s string) (string, error) { v, err := ParseTitlePosition(s); return string(v), err }, string(Inside)},
+	}
+	for _, tt := range tests {
+		got, err := tt.got(tt.in)
+		if err != nil {
+			t.Errorf("parsing %q returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsing %q: expected %s, got %s", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestParseRegisteredStyle(t *testing.T) {
+	if err := RegisterStyle("ParseHouse", StyleGlyphs{
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		Horizontal: "-", Vertical: "|",
+	}); err != nil {
+		t.Fatalf("RegisterStyle returned error: %v", err)
+	}
+	got, err := ParseBoxStyle("parsehouse")
+	if err != nil || got != "ParseHouse" {
+		t.Errorf("expected ParseHouse, got %q, %v", got, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := ParseBoxStyle("roundd")
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a *ParseError, got %T: %v", err, err)
+	}
+	if perr.Type != "BoxStyle" || perr.Value != "roundd" || !slices.Contains(perr.Valid, "Round") {
+		t.Errorf("unexpected error fields: %+v", perr)
+	}
+
+	_, err = ParseAlignType("middle")
+	if want := `invalid AlignType "middle": valid options are Left, Center, Right`; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+
+	_, err = ParseTitlePosition("")
+	if want := `invalid TitlePosition "": valid options are Inside, Top, Bottom`; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestTextMarshaling(t *testing.T) {
+	type config struct {
+		Style    BoxStyle      `json:"style"`
+		Align    AlignType     `json:"align"`
+		TitlePos TitlePosition `json:"title_pos"`
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"style":"double","align":"left","title_pos":"bottom"}`), &c); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if c.Style != Double || c.Align != Left || c.TitlePos != Bottom {
+		t.Errorf("unexpected config: %+v", c)
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if want := `{"style":"Double","align":"Left","title_pos":"Bottom"}`; string(out) != want {
+		t.Errorf("expected %s, got %s", want, out)
+	}
+
+	err = json.Unmarshal([]byte(`{"style":"nope"}`), &c)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected a *ParseError, got %T: %v", err, err)
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	style, align, pos := Single, Center, Inside
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&style, "style", "box style")
+	fs.Var(&align, "align", "content alignment")
+	fs.Var(&pos, "title-pos", "title position")
+
+	if err := fs.Parse([]string{"--style", "round", "--align", "right", "--title-pos", "top"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if style != Round || align != Right || pos != Top {
+		t.Errorf("unexpected flags: %s %s %s", style, align, pos)
+	}
+
+	err := fs.Parse([]string{"--align", "middle"})
+	if err == nil || !strings.Contains(err.Error(), "valid options are Left, Center, Right") {
+		t.Errorf("expected error listing the valid alignments, got %v", err)
+	}
+}
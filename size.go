@@ -0,0 +1,108 @@
+package box
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// validateSize checks the width and height constraints and the overflow
+// policy, reporting every problem found.
+func (b *Box) validateSize() error {
+	var errs []error
+	if b.minWidth < 0 || b.maxWidth < 0 {
+		errs = append(errs, configError("Width", min(b.minWidth, b.maxWidth), ErrNegativeValue, "width cannot be negative"))
+	} else if b.maxWidth > 0 && b.minWidth > b.maxWidth {
+		errs = append(errs, configError("MinWidth", b.minWidth, ErrInvalidSize, "minimum width %d exceeds maximum width %d", b.minWidth, b.maxWidth))
+	}
+	if b.minHeight < 0 || b.maxHeight < 0 {
+		errs = append(errs, configError("Height", min(b.minHeight, b.maxHeight), ErrNegativeValue, "height cannot be negative"))
+	} else if b.maxHeight > 0 && b.minHeight > b.maxHeight {
+		errs = append(errs, configError("MinHeight", b.minHeight, ErrInvalidSize, "minimum height %d exceeds maximum height %d", b.minHeight, b.maxHeight))
+	}
+	switch b.overflow {
+	case "", OverflowWrap, OverflowTruncate, OverflowError:
+	default:
+		errs = append(errs, configError("Overflow", b.overflow, ErrInvalidOverflow, "invalid Overflow %s", b.overflow))
+	}
+	return errors.Join(errs...)
+}
+
+// validateSpacing checks that padding and margins are not negative,
+// reporting every problem found.
+func (b *Box) validateSpacing() error {
+	var errs []error
+	if b.padLeft < 0 || b.padRight < 0 {
+		errs = append(errs, configError("HPadding", min(b.padLeft, b.padRight), ErrNegativeValue, "horizontal padding cannot be negative"))
+	}
+	if b.padTop < 0 || b.padBottom < 0 {
+		errs = append(errs, configError("VPadding", min(b.padTop, b.padBottom), ErrNegativeValue, "vertical padding cannot be negative"))
+	}
+	if b.marginTop < 0 || b.marginRight < 0 || b.marginBottom < 0 || b.marginLeft < 0 {
+		errs = append(errs, configError("Margin", min(b.marginTop, b.marginRight, b.marginBottom, b.marginLeft), ErrNegativeValue, "margin cannot be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// applyMargin surrounds the rendered box s with the configured margins.
+// Margin lines are filled with spaces so the result stays rectangular.
+func (b *Box) applyMargin(s string) string {
+	if b.marginTop == 0 && b.marginRight == 0 && b.marginBottom == 0 && b.marginLeft == 0 {
+		return s
+	}
+	blk := newBlock(s)
+	width := b.marginLeft + blk.width + b.marginRight
+	blank := strings.Repeat(" ", width)
+
+	var sb strings.Builder
+	for range b.marginTop {
+		sb.WriteString(blank + "\n")
+	}
+	left, right := strings.Repeat(" ", b.marginLeft), strings.Repeat(" ", b.marginRight)
+	for _, line := range blk.lines {
+		sb.WriteString(left + padLine(line, blk.width) + right + "\n")
+	}
+	for range b.marginBottom {
+		sb.WriteString(blank + "\n")
+	}
+	return sb.String()
+}
+
+// fitHeight keeps the leading lines, and the section dividers among them,
+// that fit in rows. Every line takes one row and every divider takes
+// dividerRows more. It reports whether any line was dropped.
+func fitHeight(lines []string, dividers []int, rows, dividerRows int) ([]string, []int, bool) {
+	used, n := 0, 0
+	var kept []int
+	for i := range lines {
+		cost := 1
+		isDivider := len(kept) < len(dividers) && dividers[len(kept)] == i
+		if isDivider {
+			cost += dividerRows
+		}
+		if used+cost > rows {
+			break
+		}
+		if isDivider {
+			kept = append(kept, i)
+		}
+		used += cost
+		n++
+	}
+	return lines[:n], kept, n < len(lines)
+}
+
+// truncateLines cuts lines wider than width, ending them with ellipsis. The
+// cut never splits escape sequences or wide characters.
+func truncateLines(lines []expandedLine, width int, ellipsis string) []expandedLine {
+	for i, l := range lines {
+		if runewidth.StringWidth(ansi.Strip(l.line)) <= width {
+			continue
+		}
+		line := ansi.Truncate(l.line, width, ellipsis)
+		lines[i] = expandedLine{line, runewidth.StringWidth(ansi.Strip(line))}
+	}
+	return lines
+}
@@ -0,0 +1,35 @@
+package box
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFitHeight(t *testing.T) {
+	lines := []string{"a", "b", "c", "d"}
+
+	got, dividers, cut := fitHeight(lines, []int{2}, 4, 1)
+	if !slices.Equal(got, []string{"a", "b", "c"}) || !slices.Equal(dividers, []int{2}) || !cut {
+		t.Errorf("unexpected fit: %q %v %v", got, dividers, cut)
+	}
+
+	// A divider that does not fit is dropped with its section.
+	got, dividers, cut = fitHeight(lines, []int{2}, 3, 1)
+	if !slices.Equal(got, []string{"a", "b"}) || len(dividers) != 0 || !cut {
+		t.Errorf("unexpected fit: %q %v %v", got, dividers, cut)
+	}
+
+	got, _, cut = fitHeight(lines, nil, 10, 1)
+	if len(got) != 4 || cut {
+		t.Errorf("expected all lines to fit, got %q %v", got, cut)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	lines := []expandedLine{{"hello world", 11}, {"ok", 2}, {"界界界", 6}, {"\x1b[31mredtext\x1b[0m", 7}}
+	got := truncateLines(lines, 5, "…")
+	want := []expandedLine{{"hell…", 5}, {"ok", 2}, {"界界…", 5}, {"\x1b[31mredt…\x1b[0m", 5}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
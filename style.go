@@ -0,0 +1,107 @@
+package box
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// StyleGlyphs describes the glyphs of a box style preset.
+//
+// TopLeft, TopRight, BottomLeft, BottomRight, Horizontal and Vertical are
+// required. The junction glyphs fall back like their Box setters: TopTee,
+// BottomTee, Cross and Divider to Horizontal, LeftTee and RightTee to the
+// wall they meet. TopEdge and BottomEdge fall back to Horizontal, LeftEdge
+// and RightEdge to Vertical.
+type StyleGlyphs struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	Horizontal  string
+	Vertical    string
+
+	TopTee    string
+	BottomTee string
+	LeftTee   string
+	RightTee  string
+	Cross     string
+	Divider   string
+
+	TopEdge    string
+	BottomEdge string
+	LeftEdge   string
+	RightEdge  string
+}
+
+var (
+	// stylesMu guards boxes and registered.
+	stylesMu sync.RWMutex
+	// registered holds the names of styles added with RegisterStyle, in
+	// registration order.
+	registered []BoxStyle
+)
+
+// RegisterStyle adds a named style preset that can then be selected with
+// Style like the built-in ones, e.g. a house style defined once and chosen
+// by name from configuration files. Registering an existing custom name
+// replaces its glyphs.
+//
+// It returns a *ConfigError if name is empty, if a required glyph is
+// missing, or if name matches another style ignoring case, since
+// ParseBoxStyle could not tell them apart. Built-in styles cannot be
+// overridden. RegisterStyle is safe for concurrent use.
+func RegisterStyle(name BoxStyle, glyphs StyleGlyphs) error {
+	if name == "" {
+		return configError("Style", name, ErrInvalidStyle, "style name cannot be empty")
+	}
+	required := []struct{ field, glyph string }{
+		{"TopLeft", glyphs.TopLeft},
+		{"TopRight", glyphs.TopRight},
+		{"BottomLeft", glyphs.BottomLeft},
+		{"BottomRight", glyphs.BottomRight},
+		{"Horizontal", glyphs.Horizontal},
+		{"Vertical", glyphs.Vertical},
+	}
+	for _, r := range required {
+		if r.glyph == "" {
+			return configError(r.field, r.glyph, ErrInvalidStyle, "style %s is missing the %s glyph", name, r.field)
+		}
+	}
+
+	stylesMu.Lock()
+	defer stylesMu.Unlock()
+	for _, existing := range styleOrder {
+		if strings.EqualFold(string(existing), string(name)) {
+			return configError("Style", name, ErrConflict, "cannot override built-in style %s", existing)
+		}
+	}
+	for _, existing := range registered {
+		if existing != name && strings.EqualFold(string(existing), string(name)) {
+			return configError("Style", name, ErrConflict, "style %s conflicts with the registered style %s", name, existing)
+		}
+	}
+	if _, ok := boxes[name]; !ok {
+		registered = append(registered, name)
+	}
+	boxes[name] = glyphs
+	return nil
+}
+
+// LookupStyle returns the glyphs of the named style and whether it exists.
+// It is safe for concurrent use.
+func LookupStyle(name BoxStyle) (StyleGlyphs, bool) {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+	glyphs, ok := boxes[name]
+	return glyphs, ok
+}
+
+// Styles returns the names of the available box styles: the built-in ones
+// followed by those added with RegisterStyle, in registration order. It is
+// safe for concurrent use.
+func Styles() []BoxStyle {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+	return slices.Concat(styleOrder, registered)
+}
@@ -0,0 +1,118 @@
+package box
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRegisterStyle(t *testing.T) {
+	house := StyleGlyphs{
+		TopLeft: "╒", TopRight: "╕", BottomLeft: "╘", BottomRight: "╛",
+		Horizontal: "═", Vertical: "│", Divider: "─",
+	}
+	if err := RegisterStyle("House", house); err != nil {
+		t.Fatalf("RegisterStyle returned error: %v", err)
+	}
+
+	got, ok := LookupStyle("House")
+	if !ok || got != house {
+		t.Fatalf("expected LookupStyle to return the registered glyphs, got %+v, %v", got, ok)
+	}
+	if styles := Styles(); !slices.Contains(styles, "House") || styles[0] != Single {
+		t.Errorf("expected Styles to list built-ins then House, got %v", styles)
+	}
+
+	out, err := NewBox().Style("House").RenderSections("", "a", "b")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	// Unset tees fall back to the walls.
+	if want := "╒═╕\n│a│\n│─│\n│b│\n╘═╛\n"; out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	// Registering again replaces the glyphs without listing the name twice.
+	house.Horizontal = "-"
+	if err := RegisterStyle("House", house); err != nil {
+		t.Fatalf("RegisterStyle returned error: %v", err)
+	}
+	if out := NewBox().Style("House").MustRender("", "a"); !strings.HasPrefix(out, "╒-╕") {
+		t.Errorf("expected replaced glyphs, got:\n%s", out)
+	}
+	count := 0
+	for _, s := range Styles() {
+		if s == "House" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected House listed once, got %d", count)
+	}
+}
+
+func TestRegisterStyleErrors(t *testing.T) {
+	full := StyleGlyphs{TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+", Horizontal: "-", Vertical: "|"}
+	missing := full
+	missing.Vertical = ""
+
+	cases := []struct {
+		name   string
+		style  BoxStyle
+		glyphs StyleGlyphs
+		want   string
+	}{
+		{"empty name", "", full, "style name cannot be empty"},
+		{"built-in", Single, full, "cannot override built-in style Single"},
+		{"missing glyph", "Broken", missing, "style Broken is missing the Vertical glyph"},
+		{"built-in in another case", "round", full, "cannot override built-in style Round"},
+		{"registered in another case", "CASEHOUSE", full, "style CASEHOUSE conflicts with the registered style CaseHouse"},
+	}
+	if err := RegisterStyle("CaseHouse", full); err != nil {
+		t.Fatalf("RegisterStyle returned error: %v", err)
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterStyle(tc.style, tc.glyphs)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+			var cerr *ConfigError
+			if !errors.As(err, &cerr) || !(errors.Is(err, ErrInvalidStyle) || errors.Is(err, ErrConflict)) {
+				t.Errorf("expected a *ConfigError wrapping a sentinel, got %#v", err)
+			}
+		})
+	}
+	if got, err := ParseBoxStyle("round"); err != nil || got != Round {
+		t.Errorf("expected round to keep selecting the built-in style, got %q, %v", got, err)
+	}
+	if _, ok := LookupStyle("Broken"); ok {
+		t.Errorf("expected a rejected style not to be registered")
+	}
+	if got, _ := LookupStyle(Single); got.TopLeft != "┌" {
+		t.Errorf("expected the built-in Single style unchanged, got %+v", got)
+	}
+}
+
+func TestRegisterStyleConcurrent(t *testing.T) {
+	glyphs := StyleGlyphs{TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+", Horizontal: "-", Vertical: "|"}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := BoxStyle(fmt.Sprintf("Concurrent%d", i))
+			if err := RegisterStyle(name, glyphs); err != nil {
+				t.Errorf("RegisterStyle returned error: %v", err)
+			}
+			if _, err := NewBox().Style(name).Render("", "x"); err != nil {
+				t.Errorf("Render returned error: %v", err)
+			}
+			_ = Styles()
+		}()
+	}
+	wg.Wait()
+}
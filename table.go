@@ -0,0 +1,515 @@
+package box
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Table holds tabular data that a Box renders with RenderTable.
+//
+// Column and row separators are drawn with the junction glyphs of the box
+// style (TopTee, BottomTee, LeftTee, RightTee, and Cross) so they connect to
+// the outer border.
+type Table struct {
+	headers       [][]string  // Header rows, separated from the body by a divider.
+	rows          [][]string  // Body rows.
+	aligns        []AlignType // Per-column alignment; missing entries use the box's ContentAlign.
+	rowSeparators bool        // Whether body rows are separated by dividers.
+}
+
+// NewTable creates an empty Table.
+func NewTable() *Table {
+	return &Table{}
+}
+
+// Header appends a header row. Header rows are rendered above the body and
+// separated from it by a divider.
+func (t *Table) Header(cells ...string) *Table {
+	t.headers = append(t.headers, cells)
+	return t
+}
+
+// Row appends a body row. Cells may span multiple lines; rows with fewer
+// cells than the widest row are padded with empty cells.
+func (t *Table) Row(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// ColumnAlign sets the horizontal alignment of each column, in order.
+//
+// Columns without an explicit alignment use the box's ContentAlign.
+func (t *Table) ColumnAlign(aligns ...AlignType) *Table {
+	t.aligns = aligns
+	return t
+}
+
+// RowSeparators enables or disables dividers between body rows.
+func (t *Table) RowSeparators(enabled bool) *Table {
+	t.rowSeparators = enabled
+	return t
+}
+
+// validateTableSize reports the size options, which tables do not support.
+func (b *Box) validateTableSize() error {
+	var errs []error
+	for _, o := range []struct {
+		field string
+		value any
+		set   bool
+	}{
+		{"MinWidth", b.minWidth, b.minWidth != 0},
+		{"MaxWidth", b.maxWidth, b.maxWidth != 0},
+		{"MinHeight", b.minHeight, b.minHeight != 0},
+		{"MaxHeight", b.maxHeight, b.maxHeight != 0},
+		{"Overflow", b.overflow, b.overflow != ""},
+	} {
+		if o.set {
+			errs = append(errs, configError(o.field, o.value, ErrConflict, "%s is not supported by tables", o.field))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// tableCell is a tab-expanded table cell and its visible width.
+type tableCell struct {
+	lines []string
+	width int
+}
+
+// MustRenderTable is like RenderTable but panics if an error occurs.
+func (b *Box) MustRenderTable(title string, t *Table) string {
+	s, err := b.RenderTable(title, t)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// RenderTable renders the table inside the box with the given title.
+//
+// Horizontal padding is applied on both sides of every cell. Vertical
+// padding and wrapping do not apply to tables, and neither do the size
+// options: Width, Height, MinWidth, MaxWidth, MinHeight, MaxHeight and
+// Overflow.
+//
+// It returns an error if the table has no columns, if any size option is
+// set, or for the same configuration problems Render reports.
+func (b *Box) RenderTable(title string, t *Table) (string, error) {
+	return b.renderTable(os.Stdout, title, t)
+}
+
+// RenderTableTo renders the table inside the box and writes it to w. Like
+// RenderTo, the color profile is derived from w itself.
+//
+// It returns the same errors as RenderTable, or the error reported by w.
+func (b *Box) RenderTableTo(w io.Writer, title string, t *Table) error {
+	s, err := b.renderTable(w, title, t)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// renderTable renders the table inside the box, formatted for the output w.
+
+func (b *Box) renderTable(w io.Writer, title string, t *Table) (string, error) {
+	if err := errors.Join(b.validate(title), b.validateTableSize()); err != nil {
+		return "", err
+	}
+	titlePos := b.titlePosition()
+
+	cols := 0
+	for _, row := range slices.Concat(t.headers, t.rows) {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return "", configError("Table", t, ErrEmptyTable, "table has no columns")
+	}
+
+	p := b.outputProfile(w)
+	title, err := applyTextColor(title, b.titleColor, b.titleGrad, 0, p)
+	if err != nil {
+		return "", err
+	}
+	title = applyAttrs(title, b.titleAttrs, p)
+	if titlePos != Inside {
+		if title, err = applyBackground(title, b.titleBg, p); err != nil {
+			return "", err
+		}
+	}
+	footer, err := applyColor(b.footer, b.footerColor, p)
+	if err != nil {
+		return "", err
+	}
+
+	headers, err := b.tableCells(t.headers, cols, p)
+	if err != nil {
+		return "", err
+	}
+	rows, err := b.tableCells(t.rows, cols, p)
+	if err != nil {
+		return "", err
+	}
+
+	// Width of each column between separators, including padding.
+	cellWidths := make([]int, cols)
+	for _, row := range slices.Concat(headers, rows) {
+		for c, cell := range row {
+			cellWidths[c] = max(cellWidths[c], cell.width+b.padLeft+b.padRight)
+		}
+	}
+
+	verticalWidth := charWidth(b.vertical)
+	innerWidth := (cols - 1) * verticalWidth
+	for _, cw := range cellWidths {
+		innerWidth += cw
+	}
+
+	// Widen the last column when the title or border labels need more room
+	// than the cells.
+	topLabels, bottomLabels, err := b.borderLabels(title, footer, titlePos, p)
+	if err != nil {
+		return "", err
+	}
+	titleWidth := max(labelsMinWidth(topLabels), labelsMinWidth(bottomLabels))
+	if title != "" && titlePos == Inside {
+		titleWidth, _ = longestLine(strings.Split(title, "\n"))
+		titleWidth = max(titleWidth+b.padLeft+b.padRight, labelsMinWidth(topLabels), labelsMinWidth(bottomLabels))
+	}
+	if titleWidth > innerWidth {
+		cellWidths[cols-1] += titleWidth - innerWidth
+		innerWidth = titleWidth
+	}
+
+	aligns := make([]AlignType, cols)
+	for c := range aligns {
+		aligns[c] = b.contentAlign
+		if c < len(t.aligns) {
+			aligns[c] = t.aligns[c]
+		}
+		if _, _, err := horizOffsets(0, 0, aligns[c]); err != nil {
+			return "", configError("ColumnAlign", aligns[c], ErrInvalidAlignment, "%s", err)
+		}
+	}
+
+	tb := tableBuilder{box: b, cellWidths: cellWidths, aligns: aligns}
+	tb.leftWall, tb.rightWall = b.sideWalls()
+	for _, c := range []struct {
+		dst *color.Color
+		src string
+	}{
+		{&tb.chrome, b.color},
+		{&tb.background, b.background},
+		{&tb.cellBg, b.innerBackground()},
+		{&tb.titleBg, b.titleBackground()},
+	} {
+		if c.src == "" {
+			continue
+		}
+		if *c.dst, err = getConvertedColor(c.src, p); err != nil {
+			return "", err
+		}
+	}
+	if tb.space, err = b.labelSpace(p); err != nil {
+		return "", err
+	}
+	// A gradient or part colors need the size of the whole table up front.
+	height := 0
+	if !b.hideTop {
+		height++
+	}
+	if title != "" && titlePos == Inside {
+		height += len(strings.Split(title, "\n")) + 1
+	}
+	for _, row := range headers {
+		height += rowHeight(row)
+	}
+	if len(headers) > 0 && len(rows) > 0 {
+		height++
+	}
+	for i, row := range rows {
+		if i > 0 && t.rowSeparators {
+			height++
+		}
+		height += rowHeight(row)
+	}
+	if !b.hideBottom {
+		height++
+	}
+	if tb.painter, err = b.borderPainter(height, innerWidth+wallWidth(tb.leftWall)+wallWidth(tb.rightWall), p); err != nil {
+		return "", err
+	}
+
+	dividerGlyph := glyphOr(b.divider, b.horizontal)
+	// Glyphs on a hidden wall are dropped along with it.
+	onLeft, onRight := tb.leftWall != "", tb.rightWall != ""
+	leftTee, rightTee := glyphIf(glyphOr(b.leftTee, tb.leftWall), onLeft), glyphIf(glyphOr(b.rightTee, tb.rightWall), onRight)
+	topEdge, bottomEdge := glyphOr(b.topEdge, b.horizontal), glyphOr(b.bottomEdge, b.horizontal)
+	var lines []string
+
+	topJunction := glyphOr(b.topTee, topEdge)
+	if title != "" && titlePos == Inside {
+		// No column meets the top bar, so the junction is plain fill as wide
+		// as a separator.
+		topJunction = buildSegment(topEdge, verticalWidth, charWidth(topEdge))
+	}
+	if !b.hideTop {
+		bar, err := tb.titledBar(glyphIf(b.topLeft, onLeft), topEdge, topJunction, glyphIf(b.topRight, onRight), topLabels, len(lines))
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, bar)
+	}
+	if title != "" && titlePos == Inside {
+		for _, l := range strings.Split(title, "\n") {
+			lines = append(lines, tb.spanLine(l, innerWidth, len(lines)))
+		}
+		lines = append(lines, tb.bar(leftTee, dividerGlyph, glyphOr(b.topTee, dividerGlyph), rightTee, len(lines)))
+	}
+
+	divider := func() string {
+		return tb.bar(leftTee, dividerGlyph, glyphOr(b.cross, dividerGlyph), rightTee, len(lines))
+	}
+	for _, row := range headers {
+		rowLines, err := tb.rowLines(row, len(lines))
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, rowLines...)
+	}
+	if len(headers) > 0 && len(rows) > 0 {
+		lines = append(lines, divider())
+	}
+	for i, row := range rows {
+		if i > 0 && t.rowSeparators {
+			lines = append(lines, divider())
+		}
+		rowLines, err := tb.rowLines(row, len(lines))
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, rowLines...)
+	}
+
+	if !b.hideBottom {
+		bar, err := tb.titledBar(glyphIf(b.bottomLeft, onLeft), bottomEdge, glyphOr(b.bottomTee, bottomEdge), glyphIf(b.bottomRight, onRight), bottomLabels, len(lines))
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, bar)
+	}
+
+	return b.applyMargin(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// tableCells colors and measures the cells of rows, padding every row to
+// cols cells.
+func (b *Box) tableCells(rows [][]string, cols int, p colorprofile.Profile) ([][]tableCell, error) {
+	out := make([][]tableCell, len(rows))
+	for r, row := range rows {
+		out[r] = make([]tableCell, cols)
+		for c := range out[r] {
+			if c >= len(row) {
+				continue
+			}
+			text, err := applyTextColor(row[c], b.contentColor, b.contentGrad, 0, p)
+			if err != nil {
+				return nil, err
+			}
+			text = applyAttrs(text, b.contentAttrs, p)
+			width, expanded := longestLine(strings.Split(text, "\n"))
+			lines := make([]string, len(expanded))
+			for i, l := range expanded {
+				lines[i] = l.line
+			}
+			out[r][c] = tableCell{lines: lines, width: width}
+		}
+	}
+	return out, nil
+}
+
+// glyphOr returns glyph, or fallback when glyph is empty.
+func glyphOr(glyph, fallback string) string {
+	if glyph == "" {
+		return fallback
+	}
+	return glyph
+}
+
+// tableBuilder draws the lines of a table.
+type tableBuilder struct {
+	box        *Box
+	chrome     color.Color    // Converted border color; nil leaves chrome unstyled.
+	painter    *borderPainter // Colors chrome by position; overrides chrome when set.
+	space      string         // Space framing border labels.
+	background color.Color    // Converted border background; nil leaves it unset.
+	cellBg     color.Color    // Converted background of the cells.
+	titleBg    color.Color    // Converted background of Inside title lines.
+	cellWidths []int
+	aligns     []AlignType
+	leftWall   string // Left wall glyph; empty when hidden.
+	rightWall  string // Right wall glyph; empty when hidden.
+}
+
+// paint colors border chrome starting at column col of the given row.
+func (tb tableBuilder) paint(s string, row, col int) string {
+	if tb.painter != nil {
+		s = tb.painter.paint(s, row, col)
+	} else {
+		s = applyConvertedColor(s, tb.chrome)
+	}
+	return applyConvertedBackground(s, tb.background)
+}
+
+// segments returns the fill for each column joined by junction.
+func (tb tableBuilder) segments(fill, junction string) string {
+	fillWidth := charWidth(fill)
+	segs := make([]string, len(tb.cellWidths))
+	for i, w := range tb.cellWidths {
+		segs[i] = buildSegment(fill, w, fillWidth)
+	}
+	return strings.Join(segs, junction)
+}
+
+// bar builds a horizontal bar with a junction at every column boundary.
+func (tb tableBuilder) bar(left, fill, junction, right string, row int) string {
+	return tb.paint(left+tb.segments(fill, junction)+right, row, 0)
+}
+
+// titledBar builds a horizontal bar with the labels laid over it in their
+// slots. Junctions hidden by a label are dropped.
+func (tb tableBuilder) titledBar(left, fill, junction, right string, labels [3]string, row int) (string, error) {
+	inner := tb.segments(fill, junction)
+	if labels == [3]string{} {
+		return tb.paint(left+inner+right, row, 0), nil
+	}
+	innerWidth := visibleWidth(inner)
+	segs, widths := labelSegments(labels)
+	starts, err := layoutLabels(innerWidth, widths)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	leftWidth := wallWidth(left)
+	sb.WriteString(tb.paint(left, row, 0))
+	cursor := 0
+	for i, seg := range segs {
+		if seg == "" {
+			continue
+		}
+		if tb.space != " " {
+			seg = tb.space + seg[1:len(seg)-1] + tb.space
+		}
+		run := tb.barRun(fill, junction, cursor, starts[i], false)
+		sb.WriteString(tb.paint(run, row, leftWidth+cursor) + applyConvertedBackground(seg, tb.background))
+		cursor = starts[i] + widths[i]
+	}
+	// The last run ends at the corner, so its gap goes next to the label.
+	run := tb.barRun(fill, junction, cursor, innerWidth, true)
+	sb.WriteString(tb.paint(run+right, row, leftWidth+cursor))
+	return sb.String(), nil
+}
+
+// barRun builds the columns from to to of a bar's inner width, between
+// labels. Fill glyphs are laid out with titledBarSide like the box bars, and
+// the junctions lying wholly inside the range are kept. The spaces left over
+// by a wide fill glyph go at the start of each piece when gapFirst is set,
+// and at its end otherwise.
+func (tb tableBuilder) barRun(fill, junction string, from, to int, gapFirst bool) string {
+	fillWidth, junctionWidth := charWidth(fill), charWidth(junction)
+	var sb strings.Builder
+	piece := func(width int) {
+		run, gap := titledBarSide(fill, width, fillWidth)
+		if gapFirst {
+			sb.WriteString(gap + run)
+		} else {
+			sb.WriteString(run + gap)
+		}
+	}
+	start := 0
+	for c, w := range tb.cellWidths {
+		end := start + w
+		if lo, hi := max(start, from), min(end, to); hi > lo {
+			piece(hi - lo)
+		}
+		if c == len(tb.cellWidths)-1 {
+			break
+		}
+		next := end + junctionWidth
+		switch lo, hi := max(end, from), min(next, to); {
+		case lo == end && hi == next:
+			sb.WriteString(junction)
+		case hi > lo:
+			// A label covers part of the junction.
+			sb.WriteString(strings.Repeat(" ", hi-lo))
+		}
+		start = next
+	}
+	return sb.String()
+}
+
+// spanLine renders an Inside title line across the full inner width,
+// centered unless the box has an explicit TitleAlign.
+func (tb tableBuilder) spanLine(text string, innerWidth, row int) string {
+	_, expanded := longestLine([]string{text})
+	text = expanded[0].line
+	align := tb.box.titleAlign
+	if align == "" {
+		align = Center
+	}
+	left, right, _ := horizOffsets(visibleWidth(text), innerWidth-tb.box.padLeft-tb.box.padRight, align)
+	leftMargin, rightMargin := strings.Repeat(" ", tb.box.padLeft), strings.Repeat(" ", tb.box.padRight)
+	inner := leftMargin + strings.Repeat(" ", left) + text + strings.Repeat(" ", right) + rightMargin
+	leftWidth := wallWidth(tb.leftWall)
+	return tb.paint(tb.leftWall, row, 0) + applyConvertedBackground(inner, tb.titleBg) + tb.paint(tb.rightWall, row, leftWidth+innerWidth)
+}
+
+// rowHeight returns the number of lines taken by a table row.
+func rowHeight(row []tableCell) int {
+	height := 1
+	for _, cell := range row {
+		height = max(height, len(cell.lines))
+	}
+	return height
+}
+
+// rowLines renders one table row, which may span several lines, starting at
+// line y of the table.
+func (tb tableBuilder) rowLines(row []tableCell, y int) ([]string, error) {
+	verticalWidth := charWidth(tb.box.vertical)
+	leftMargin, rightMargin := strings.Repeat(" ", tb.box.padLeft), strings.Repeat(" ", tb.box.padRight)
+	lines := make([]string, rowHeight(row))
+	for r := range lines {
+		var sb strings.Builder
+		sb.WriteString(tb.paint(tb.leftWall, y+r, 0))
+		col := wallWidth(tb.leftWall)
+		for c, cell := range row {
+			if c > 0 {
+				sb.WriteString(tb.paint(tb.box.vertical, y+r, col))
+				col += verticalWidth
+			}
+			text := ""
+			if r < len(cell.lines) {
+				text = cell.lines[r]
+			}
+			left, right, err := horizOffsets(visibleWidth(text), tb.cellWidths[c]-tb.box.padLeft-tb.box.padRight, tb.aligns[c])
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(applyConvertedBackground(leftMargin+strings.Repeat(" ", left)+text+strings.Repeat(" ", right)+rightMargin, tb.cellBg))
+			col += tb.cellWidths[c]
+		}
+		sb.WriteString(tb.paint(tb.rightWall, y+r, col))
+		lines[r] = sb.String()
+	}
+	return lines, nil
+}
@@ -0,0 +1,396 @@
+package box
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestRenderTable(t *testing.T) {
+	tbl := NewTable().
+		Header("Name", "Value").
+		Row("cpu", "42%").
+		Row("memory", "1.2 GiB\nswap 0").
+		ColumnAlign(Left, Right)
+
+	out, err := NewBox().Style(Single).Padding(1, 0).RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+
+	want := "" +
+		"┌────────┬─────────┐\n" +
+		"│ Name   │   Value │\n" +
+		"├────────┼─────────┤\n" +
+		"│ cpu    │     42% │\n" +
+		"│ memory │ 1.2 GiB │\n" +
+		"│        │  swap 0 │\n" +
+		"└────────┴─────────┘\n"
+	if out != want {
+		t.Errorf("unexpected table:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRenderTableJunctionsPerStyle(t *testing.T) {
+	tbl := NewTable().Header("a", "b").Row("1", "2").Row("3", "4").RowSeparators(true)
+
+	for _, style := range []BoxStyle{Single, Double, Round, Bold, SingleDouble, DoubleSingle, Classic, Block} {
+		preset := boxes[style]
+		out, err := NewBox().Style(style).Padding(1, 0).RenderTable("", tbl)
+		if err != nil {
+			t.Fatalf("style %q: RenderTable returned error: %v", style, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		if !strings.Contains(lines[0], preset.TopTee) {
+			t.Errorf("style %q: expected top tee %q in %q", style, preset.TopTee, lines[0])
+		}
+		if !strings.HasPrefix(lines[2], preset.LeftTee) || !strings.HasSuffix(lines[2], preset.RightTee) || !strings.Contains(lines[2], preset.Cross) {
+			t.Errorf("style %q: unexpected divider %q", style, lines[2])
+		}
+		if !strings.Contains(lines[len(lines)-1], preset.BottomTee) {
+			t.Errorf("style %q: expected bottom tee %q in %q", style, preset.BottomTee, lines[len(lines)-1])
+		}
+		// Header divider plus one separator between the two body rows.
+		if got := strings.Count(out, preset.LeftTee); style != Classic && style != Block && got != 2 {
+			t.Errorf("style %q: expected 2 dividers, got %d", style, got)
+		}
+	}
+}
+
+func TestRenderTableTitlesAndColors(t *testing.T) {
+	tbl := NewTable().Header("Service", "Status").Row("api", "\x1b[32mup\x1b[0m").Row("盒子", "down")
+
+	for _, pos := range []TitlePosition{Inside, Top, Bottom} {
+		b := NewBox().Style(Round).Padding(1, 0).TitlePosition(pos).Color(Cyan).TitleColor(Yellow).ContentColor(White)
+		out, err := b.RenderTable("A rather long table title", tbl)
+		if err != nil {
+			t.Fatalf("%s: RenderTable returned error: %v", pos, err)
+		}
+		plain := ansi.Strip(out)
+		if !strings.Contains(plain, "A rather long table title") {
+			t.Errorf("%s: expected title in output:\n%s", pos, plain)
+		}
+		lines := strings.Split(strings.TrimSuffix(plain, "\n"), "\n")
+		width := runewidth.StringWidth(lines[0])
+		for i, l := range lines {
+			if w := runewidth.StringWidth(l); w != width {
+				t.Errorf("%s: line %d has width %d, expected %d: %q", pos, i, w, width, l)
+			}
+		}
+	}
+}
+
+func TestRenderTableBackground(t *testing.T) {
+	tbl := NewTable().Header("a", "b").Row("ccc", "d")
+	b := NewBox().Padding(1, 0).Background(Blue).ContentBackground(Black).TitleBackground(Red).ColorProfile(colorprofile.ANSI)
+	out, err := b.RenderTable("T", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	want := "" +
+		"\x1b[44m┌─────────┐\x1b[m\n" +
+		"\x1b[44m│\x1b[m\x1b[41m    T    \x1b[m\x1b[44m│\x1b[m\n" +
+		"\x1b[44m├─────┬───┤\x1b[m\n" +
+		"\x1b[44m│\x1b[m\x1b[40m a   \x1b[m\x1b[44m│\x1b[m\x1b[40m b \x1b[m\x1b[44m│\x1b[m\n" +
+		"\x1b[44m├─────┼───┤\x1b[m\n" +
+		"\x1b[44m│\x1b[m\x1b[40m ccc \x1b[m\x1b[44m│\x1b[m\x1b[40m d \x1b[m\x1b[44m│\x1b[m\n" +
+		"\x1b[44m└─────┴───┘\x1b[m\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", out, want)
+	}
+}
+
+func TestRenderTableGradient(t *testing.T) {
+	tbl := NewTable().Header("a", "b").Row("1", "2")
+	b := NewBox().Padding(0, 0).ColorGradient("#ff0000", "#0000ff").ColorProfile(colorprofile.TrueColor)
+	out, err := b.RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	red, purple, blue := "\x1b[38;2;255;0;0m", "\x1b[38;2;128;0;128m", "\x1b[38;2;0;0;255m"
+	// Column separators take the color of their position.
+	if want := red + "│\x1b[ma" + purple + "│\x1b[mb" + blue + "│\x1b[m"; !strings.Contains(out, want) {
+		t.Errorf("unexpected header row in:\n%q\nwant: %q", out, want)
+	}
+
+	out, err = b.GradientDirection(GradientVertical).RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if want := purple + "├─┼─┤\x1b[m"; lines[2] != want {
+		t.Errorf("unexpected divider:\ngot:  %q\nwant: %q", lines[2], want)
+	}
+	if want := blue + "└─┴─┘\x1b[m"; lines[4] != want {
+		t.Errorf("unexpected bottom bar:\ngot:  %q\nwant: %q", lines[4], want)
+	}
+}
+
+func TestRenderTableTextGradient(t *testing.T) {
+	g := TextGradient{Stops: []string{"#ff0000", "#0000ff"}}
+	b := NewBox().Padding(0, 0).TitlePosition(Top).TitleGradient(g).ContentGradient(g).ColorProfile(colorprofile.TrueColor)
+	out, err := b.RenderTable("T!", NewTable().Row("ab", "c"))
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	red, blue := "\x1b[38;2;255;0;0m", "\x1b[38;2;0;0;255m"
+	want := "" +
+		"┌ " + red + "T\x1b[m" + blue + "!\x1b[m ┐\n" +
+		"│" + red + "a\x1b[m" + blue + "b\x1b[m│" + red + "c\x1b[m│\n" +
+		"└──┴─┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", out, want)
+	}
+}
+
+func TestRenderTablePartColors(t *testing.T) {
+	b := NewBox().Padding(0, 0).Color(Cyan).CornerColor(BrightWhite).TopEdgeColor(Green).LeftEdgeColor(Red).
+		TitlePosition(Top).LabelSpaceBackground(Blue).ColorProfile(colorprofile.ANSI)
+	out, err := b.RenderTable("T", NewTable().Row("a", "b"))
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	want := "" +
+		"\x1b[97m┌\x1b[m\x1b[44m \x1b[mT\x1b[44m \x1b[m\x1b[97m┐\x1b[m\n" +
+		"\x1b[31m│\x1b[ma\x1b[36m│\x1b[mb\x1b[36m│\x1b[m\n" +
+		"\x1b[97m└\x1b[m\x1b[36m─┴─\x1b[m\x1b[97m┘\x1b[m\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", out, want)
+	}
+}
+
+func TestRenderTableWideFillLabels(t *testing.T) {
+	// Labels in later slots used to cut a wide fill glyph and panic.
+	for _, tbl := range []*Table{
+		NewTable().Header("a", "h").Row("x", "y"),
+		NewTable().Header("aaaa", "hhhh", "zz").Row("x", "y", "z"),
+	} {
+		out, err := NewBox().Horizontal("🔥").TitlePosition(Top).TopLabel(Right, "xx").BottomLabel(Center, "b").RenderTable("a", tbl)
+		if err != nil {
+			t.Fatalf("RenderTable returned error: %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		width := runewidth.StringWidth(lines[1])
+		for i, l := range lines {
+			if w := runewidth.StringWidth(l); w != width {
+				t.Errorf("line %d has width %d, expected %d: %q", i, w, width, l)
+			}
+		}
+		if !strings.Contains(lines[0], " xx ┐") || !strings.Contains(lines[len(lines)-1], " b ") {
+			t.Errorf("expected the labels on the bars, got:\n%s", out)
+		}
+	}
+}
+
+func TestRenderTableWideFillInsideTitle(t *testing.T) {
+	// With an Inside title the top bar has no junctions; a wide fill glyph
+	// used to take the place of each one and widen the bar.
+	out, err := NewBox().Horizontal("🔥").RenderTable("Title", NewTable().Header("aa", "bb", "cc").Row("1", "2", "3"))
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	width := runewidth.StringWidth(lines[1])
+	for i, l := range lines {
+		if w := runewidth.StringWidth(l); w != width {
+			t.Errorf("line %d has width %d, expected %d: %q", i, w, width, l)
+		}
+	}
+	if lines[0] != "┌🔥 🔥 🔥┐" {
+		t.Errorf("unexpected top bar %q", lines[0])
+	}
+}
+
+func TestRenderTableConcurrent(t *testing.T) {
+	// Several headers leave spare capacity in the table's slices, which
+	// rendering must not write to.
+	tbl := NewTable().Header("a", "b").Header("c", "d").Header("e", "f").Row("1", "2")
+	b := NewBox()
+	want, err := b.RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if got, err := b.RenderTable("", tbl); err != nil || got != want {
+					t.Errorf("unexpected concurrent render: %v\n%s", err, got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRenderTableTextAttrs(t *testing.T) {
+	b := NewBox().TitleStyle(AttrBold).ContentStyle(AttrUnderline).TitlePosition(Top).ColorProfile(colorprofile.ANSI)
+	out, err := b.RenderTable("T", NewTable().Row("a", "b"))
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	want := "" +
+		"┌ \x1b[1mT\x1b[m ┐\n" +
+		"│\x1b[4ma\x1b[m│\x1b[4mb\x1b[m│\n" +
+		"└─┴─┘\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", out, want)
+	}
+}
+
+func TestRenderTableTo(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	tbl := NewTable().Header("Name", "Value").Row("a", "1")
+	b := NewBox().Padding(1, 0)
+
+	var buf bytes.Buffer
+	if err := b.RenderTableTo(&buf, "Title", tbl); err != nil {
+		t.Fatalf("RenderTableTo returned error: %v", err)
+	}
+	want, err := b.RenderTable("Title", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("RenderTableTo output differs from RenderTable:\ngot  %q\nwant %q", buf.String(), want)
+	}
+
+	// A buffer is not a terminal, so it gets plain text.
+	buf.Reset()
+	if err := b.Copy().Color(Red).RenderTableTo(&buf, "Title", tbl); err != nil {
+		t.Fatalf("RenderTableTo returned error: %v", err)
+	}
+	if out := buf.String(); out == "" || ansi.Strip(out) != out {
+		t.Errorf("expected plain text for a buffer, got %q", out)
+	}
+
+	buf.Reset()
+	if err := b.RenderTableTo(&buf, "Title", NewTable()); !errors.Is(err, ErrEmptyTable) {
+		t.Errorf("expected ErrEmptyTable, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written on error, got %q", buf.String())
+	}
+}
+
+func TestRenderTableErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		box  *Box
+		tbl  *Table
+		want string
+		is   error
+	}{
+		{"no columns", NewBox(), NewTable(), "table has no columns", ErrEmptyTable},
+		{"invalid column align", NewBox(), NewTable().Row("a").ColumnAlign(AlignType("Weird")), "invalid Content Alignment", ErrInvalidAlignment},
+		{"invalid style", NewBox().Style(BoxStyle("Weird")), NewTable().Row("a"), "invalid Box style", ErrInvalidStyle},
+		{"negative padding", NewBox().Padding(-1, 0), NewTable().Row("a"), "horizontal padding cannot be negative", ErrNegativeValue},
+		{"invalid color", NewBox().Color("NotAColor"), NewTable().Row("a"), "unable to parse color", ErrInvalidColor},
+		{"max width", NewBox().MaxWidth(5).Overflow(OverflowError), NewTable().Row("a"), "MaxWidth is not supported by tables", ErrConflict},
+		{"height", NewBox().Height(4), NewTable().Row("a"), "MinHeight is not supported by tables", ErrConflict},
+	}
+	for _, tc := range cases {
+		var cerr *ConfigError
+		if _, err := tc.box.RenderTable("", tc.tbl); err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		} else if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: unexpected error message: %v", tc.name, err)
+		} else if !errors.Is(err, tc.is) || !errors.As(err, &cerr) {
+			t.Errorf("%s: expected a *ConfigError matching %v, got %v", tc.name, tc.is, err)
+		}
+	}
+}
+
+func TestRenderTableTitleAlign(t *testing.T) {
+	tbl := NewTable().Row("alpha", "beta", "gamma")
+
+	out, err := NewBox().Style(Single).Padding(1, 0).TitlePosition(Top).TitleAlign(Right).RenderTable("T", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	if top := strings.Split(out, "\n")[0]; top != "┌───────┬──────┬──── T ┐" {
+		t.Errorf("unexpected right-aligned title bar %q", top)
+	}
+
+	out, err = NewBox().Style(Single).Padding(1, 0).TitlePosition(Bottom).TitleAlign(Center).RenderTable("T", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if bottom := lines[len(lines)-2]; bottom != "└───────┴─ T ──┴───────┘" {
+		t.Errorf("unexpected centered title bar %q", bottom)
+	}
+}
+
+func TestRenderTableBorderLabels(t *testing.T) {
+	tbl := NewTable().Row("alpha", "beta", "gamma")
+
+	out, err := NewBox().Style(Single).Padding(1, 0).TitlePosition(Top).
+		TopLabel(Right, "3").BottomLabel(Center, "ok").RenderTable("T", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if top := lines[0]; top != "┌ T ────┬──────┬──── 3 ┐" {
+		t.Errorf("unexpected top bar %q", top)
+	}
+	if bottom := lines[len(lines)-2]; bottom != "└───────┴─ ok ─┴───────┘" {
+		t.Errorf("unexpected bottom bar %q", bottom)
+	}
+
+	if _, err := NewBox().TitlePosition(Top).TopLabel(Left, "x").RenderTable("T", tbl); err == nil {
+		t.Errorf("expected error for a label conflicting with the title")
+	}
+}
+
+func TestRenderTableBorders(t *testing.T) {
+	tbl := NewTable().Header("a", "b").Row("1", "2")
+	out, err := NewBox().Borders(true, false, true, false).RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	want := "" +
+		"─┬─\n" +
+		"a│b\n" +
+		"─┼─\n" +
+		"1│2\n" +
+		"─┴─\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = NewBox().Borders(false, true, false, true).RenderTable("T", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	want = "" +
+		"│ T │\n" +
+		"├─┬─┤\n" +
+		"│a│b│\n" +
+		"├─┼─┤\n" +
+		"│1│2│\n"
+	if out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRenderTableDistinctEdges(t *testing.T) {
+	tbl := NewTable().Row("a", "b")
+	out, err := NewBox().TopEdge("▀").BottomEdge("▄").LeftEdge("▌").RightEdge("▐").RenderTable("", tbl)
+	if err != nil {
+		t.Fatalf("RenderTable returned error: %v", err)
+	}
+	if want := "┌▀┬▀┐\n▌a│b▐\n└▄┴▄┘\n"; out != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -28,8 +28,36 @@ const (
 	Hidden BoxStyle = "Hidden"
 	// Block is a box style with solid block characters.
 	Block BoxStyle = "Block"
+	// Dashed is a box style with light dashed lines.
+	Dashed BoxStyle = "Dashed"
+	// DashedBold is a box style with heavy dashed lines.
+	DashedBold BoxStyle = "DashedBold"
+	// Dotted is a box style with light dotted lines.
+	Dotted BoxStyle = "Dotted"
+	// DottedBold is a box style with heavy dotted lines.
+	DottedBold BoxStyle = "DottedBold"
+	// RoundBold is a box style with rounded corners and heavy lines.
+	RoundBold BoxStyle = "RoundBold"
+	// InnerHalfBlock is a box style with half blocks facing the content.
+	InnerHalfBlock BoxStyle = "InnerHalfBlock"
+	// OuterHalfBlock is a box style with half blocks facing away from the content.
+	OuterHalfBlock BoxStyle = "OuterHalfBlock"
+	// Markdown is a box style using pipes and minus characters, which renders
+	// tables close to Markdown syntax.
+	Markdown BoxStyle = "Markdown"
+	// RST is a box style using equals signs like reStructuredText simple tables.
+	RST BoxStyle = "RST"
+	// Ascii is a box style using only 7-bit ASCII characters with rounded-looking corners.
+	Ascii BoxStyle = "Ascii"
 )
 
+// styleOrder lists the built-in styles in the order returned by Styles.
+var styleOrder = []BoxStyle{
+	Single, Double, Round, Bold, SingleDouble, DoubleSingle, Classic, Hidden, Block,
+	Dashed, DashedBold, Dotted, DottedBold, RoundBold, InnerHalfBlock, OuterHalfBlock,
+	Markdown, RST, Ascii,
+}
+
 // AlignType represents the horizontal alignment of content inside the box.
 type AlignType string
 
@@ -42,6 +70,55 @@ const (
 	Right AlignType = "Right"
 )
 
+// VerticalAlignType represents the vertical alignment of a block relative to
+// taller neighbors or a taller area.
+type VerticalAlignType string
+
+const (
+	// AlignTop places the block at the top, adding blank lines below it.
+	AlignTop VerticalAlignType = "Top"
+	// AlignMiddle centers the block vertically.
+	AlignMiddle VerticalAlignType = "Middle"
+	// AlignBottom places the block at the bottom, adding blank lines above it.
+	AlignBottom VerticalAlignType = "Bottom"
+)
+
+// OverflowType represents how content that does not fit a box with a
+// maximum width or height is handled.
+type OverflowType string
+
+const (
+	// OverflowWrap wraps lines that are too wide. Content that is too tall
+	// cannot be wrapped and is truncated instead.
+	OverflowWrap OverflowType = "Wrap"
+	// OverflowTruncate cuts lines and rows that do not fit, marking the cut
+	// with the ellipsis.
+	OverflowTruncate OverflowType = "Truncate"
+	// OverflowError makes Render return an error when the content does not fit.
+	OverflowError OverflowType = "Error"
+)
+
+// TextAttr is a set of text attributes, combined with |, such as
+// box.AttrBold|box.AttrUnderline.
+type TextAttr uint8
+
+const (
+	// AttrBold renders text in bold.
+	AttrBold TextAttr = 1 << iota
+	// AttrDim renders text faint.
+	AttrDim
+	// AttrItalic renders text in italics.
+	AttrItalic
+	// AttrUnderline underlines text.
+	AttrUnderline
+	// AttrBlink makes text blink where the terminal supports it.
+	AttrBlink
+	// AttrReverse swaps the foreground and background colors.
+	AttrReverse
+	// AttrStrikethrough strikes text through.
+	AttrStrikethrough
+)
+
 // TitlePosition represents the position of the title relative to the box.
 type TitlePosition string
 
@@ -54,6 +131,20 @@ const (
 	Bottom TitlePosition = "Bottom"
 )
 
+// GradientDirection represents the direction along which a ColorGradient
+// fades.
+type GradientDirection string
+
+const (
+	// GradientHorizontal fades from the left edge of the box to the right.
+	GradientHorizontal GradientDirection = "Horizontal"
+	// GradientVertical fades from the top bar to the bottom bar.
+	GradientVertical GradientDirection = "Vertical"
+	// GradientPerimeter fades clockwise around the border, starting and
+	// ending at the top-left corner.
+	GradientPerimeter GradientDirection = "Perimeter"
+)
+
 // Standard and bright ANSI color name constants usable with Color,
 // TitleColor, and ContentColor.
 const (
@@ -86,78 +177,280 @@ const (
 
 var (
 	// boxes are inbuilt Box styles provided by the module
-	boxes = map[BoxStyle]Box{
+	boxes = map[BoxStyle]StyleGlyphs{
 		Single: {
-			topRight:    "┐",
-			topLeft:     "┌",
-			bottomRight: "┘",
-			bottomLeft:  "└",
-			horizontal:  "─",
-			vertical:    "│",
+			TopRight:    "┐",
+			TopLeft:     "┌",
+			BottomRight: "┘",
+			BottomLeft:  "└",
+			Horizontal:  "─",
+			Vertical:    "│",
+			TopTee:      "┬",
+			BottomTee:   "┴",
+			LeftTee:     "├",
+			RightTee:    "┤",
+			Cross:       "┼",
+			Divider:     "─",
 		},
 		Double: {
-			topRight:    "╗",
-			topLeft:     "╔",
-			bottomRight: "╝",
-			bottomLeft:  "╚",
-			horizontal:  "═",
-			vertical:    "║",
+			TopRight:    "╗",
+			TopLeft:     "╔",
+			BottomRight: "╝",
+			BottomLeft:  "╚",
+			Horizontal:  "═",
+			Vertical:    "║",
+			TopTee:      "╦",
+			BottomTee:   "╩",
+			LeftTee:     "╠",
+			RightTee:    "╣",
+			Cross:       "╬",
+			Divider:     "═",
 		},
 		Round: {
-			topRight:    "╮",
-			topLeft:     "╭",
-			bottomRight: "╯",
-			bottomLeft:  "╰",
-			horizontal:  "─",
-			vertical:    "│",
+			TopRight:    "╮",
+			TopLeft:     "╭",
+			BottomRight: "╯",
+			BottomLeft:  "╰",
+			Horizontal:  "─",
+			Vertical:    "│",
+			TopTee:      "┬",
+			BottomTee:   "┴",
+			LeftTee:     "├",
+			RightTee:    "┤",
+			Cross:       "┼",
+			Divider:     "─",
 		},
 		Bold: {
-			topRight:    "┓",
-			topLeft:     "┏",
-			bottomRight: "┛",
-			bottomLeft:  "┗",
-			horizontal:  "━",
-			vertical:    "┃",
+			TopRight:    "┓",
+			TopLeft:     "┏",
+			BottomRight: "┛",
+			BottomLeft:  "┗",
+			Horizontal:  "━",
+			Vertical:    "┃",
+			TopTee:      "┳",
+			BottomTee:   "┻",
+			LeftTee:     "┣",
+			RightTee:    "┫",
+			Cross:       "╋",
+			Divider:     "━",
 		},
 		SingleDouble: {
-			topRight:    "╖",
-			topLeft:     "╓",
-			bottomRight: "╜",
-			bottomLeft:  "╙",
-			horizontal:  "─",
-			vertical:    "║",
+			TopRight:    "╖",
+			TopLeft:     "╓",
+			BottomRight: "╜",
+			BottomLeft:  "╙",
+			Horizontal:  "─",
+			Vertical:    "║",
+			TopTee:      "╥",
+			BottomTee:   "╨",
+			LeftTee:     "╟",
+			RightTee:    "╢",
+			Cross:       "╫",
+			Divider:     "─",
 		},
 		DoubleSingle: {
-			topRight:    "╕",
-			topLeft:     "╒",
-			bottomRight: "╛",
-			bottomLeft:  "╘",
-			horizontal:  "═",
-			vertical:    "│",
+			TopRight:    "╕",
+			TopLeft:     "╒",
+			BottomRight: "╛",
+			BottomLeft:  "╘",
+			Horizontal:  "═",
+			Vertical:    "│",
+			TopTee:      "╤",
+			BottomTee:   "╧",
+			LeftTee:     "╞",
+			RightTee:    "╡",
+			Cross:       "╪",
+			Divider:     "═",
 		},
 		Classic: {
-			topRight:    "+",
-			topLeft:     "+",
-			bottomRight: "+",
-			bottomLeft:  "+",
-			horizontal:  "-",
-			vertical:    "|",
+			TopRight:    "+",
+			TopLeft:     "+",
+			BottomRight: "+",
+			BottomLeft:  "+",
+			Horizontal:  "-",
+			Vertical:    "|",
+			TopTee:      "+",
+			BottomTee:   "+",
+			LeftTee:     "+",
+			RightTee:    "+",
+			Cross:       "+",
+			Divider:     "-",
 		},
 		Hidden: {
-			topRight:    "+",
-			topLeft:     "+",
-			bottomRight: "+",
-			bottomLeft:  "+",
-			horizontal:  " ",
-			vertical:    " ",
+			TopRight:    "+",
+			TopLeft:     "+",
+			BottomRight: "+",
+			BottomLeft:  "+",
+			Horizontal:  " ",
+			Vertical:    " ",
+			TopTee:      "+",
+			BottomTee:   "+",
+			LeftTee:     "+",
+			RightTee:    "+",
+			Cross:       "+",
+			Divider:     " ",
 		},
 		Block: {
-			topRight:    "█",
-			topLeft:     "█",
-			bottomRight: "█",
-			bottomLeft:  "█",
-			horizontal:  "█",
-			vertical:    "█",
+			TopRight:    "█",
+			TopLeft:     "█",
+			BottomRight: "█",
+			BottomLeft:  "█",
+			Horizontal:  "█",
+			Vertical:    "█",
+			TopTee:      "█",
+			BottomTee:   "█",
+			LeftTee:     "█",
+			RightTee:    "█",
+			Cross:       "█",
+			Divider:     "█",
+		},
+		Dashed: {
+			TopRight:    "┐",
+			TopLeft:     "┌",
+			BottomRight: "┘",
+			BottomLeft:  "└",
+			Horizontal:  "┄",
+			Vertical:    "┆",
+			TopTee:      "┬",
+			BottomTee:   "┴",
+			LeftTee:     "├",
+			RightTee:    "┤",
+			Cross:       "┼",
+			Divider:     "┄",
+		},
+		DashedBold: {
+			TopRight:    "┓",
+			TopLeft:     "┏",
+			BottomRight: "┛",
+			BottomLeft:  "┗",
+			Horizontal:  "┅",
+			Vertical:    "┇",
+			TopTee:      "┳",
+			BottomTee:   "┻",
+			LeftTee:     "┣",
+			RightTee:    "┫",
+			Cross:       "╋",
+			Divider:     "┅",
+		},
+		Dotted: {
+			TopRight:    "┐",
+			TopLeft:     "┌",
+			BottomRight: "┘",
+			BottomLeft:  "└",
+			Horizontal:  "┈",
+			Vertical:    "┊",
+			TopTee:      "┬",
+			BottomTee:   "┴",
+			LeftTee:     "├",
+			RightTee:    "┤",
+			Cross:       "┼",
+			Divider:     "┈",
+		},
+		DottedBold: {
+			TopRight:    "┓",
+			TopLeft:     "┏",
+			BottomRight: "┛",
+			BottomLeft:  "┗",
+			Horizontal:  "┉",
+			Vertical:    "┋",
+			TopTee:      "┳",
+			BottomTee:   "┻",
+			LeftTee:     "┣",
+			RightTee:    "┫",
+			Cross:       "╋",
+			Divider:     "┉",
+		},
+		RoundBold: {
+			TopRight:    "╮",
+			TopLeft:     "╭",
+			BottomRight: "╯",
+			BottomLeft:  "╰",
+			Horizontal:  "━",
+			Vertical:    "┃",
+			TopTee:      "┳",
+			BottomTee:   "┻",
+			LeftTee:     "┣",
+			RightTee:    "┫",
+			Cross:       "╋",
+			Divider:     "━",
+		},
+		InnerHalfBlock: {
+			TopRight:    "▖",
+			TopLeft:     "▗",
+			BottomRight: "▘",
+			BottomLeft:  "▝",
+			Horizontal:  "▄",
+			Vertical:    "▐",
+			TopEdge:     "▄",
+			BottomEdge:  "▀",
+			LeftEdge:    "▐",
+			RightEdge:   "▌",
+			TopTee:      "▄",
+			BottomTee:   "▀",
+			LeftTee:     "▐",
+			RightTee:    "▌",
+			Cross:       "▐",
+			Divider:     "▄",
+		},
+		OuterHalfBlock: {
+			TopRight:    "▜",
+			TopLeft:     "▛",
+			BottomRight: "▟",
+			BottomLeft:  "▙",
+			Horizontal:  "▀",
+			Vertical:    "▌",
+			TopEdge:     "▀",
+			BottomEdge:  "▄",
+			LeftEdge:    "▌",
+			RightEdge:   "▐",
+			TopTee:      "▀",
+			BottomTee:   "▄",
+			LeftTee:     "▌",
+			RightTee:    "▐",
+			Cross:       "▌",
+			Divider:     "▀",
+		},
+		Markdown: {
+			TopRight:    "|",
+			TopLeft:     "|",
+			BottomRight: "|",
+			BottomLeft:  "|",
+			Horizontal:  "-",
+			Vertical:    "|",
+			TopTee:      "|",
+			BottomTee:   "|",
+			LeftTee:     "|",
+			RightTee:    "|",
+			Cross:       "|",
+			Divider:     "-",
+		},
+		RST: {
+			TopRight:    "=",
+			TopLeft:     "=",
+			BottomRight: "=",
+			BottomLeft:  "=",
+			Horizontal:  "=",
+			Vertical:    " ",
+			TopTee:      " ",
+			BottomTee:   " ",
+			LeftTee:     "=",
+			RightTee:    "=",
+			Cross:       " ",
+			Divider:     "=",
+		},
+		Ascii: {
+			TopRight:    ".",
+			TopLeft:     ".",
+			BottomRight: "'",
+			BottomLeft:  "'",
+			Horizontal:  "-",
+			Vertical:    "|",
+			TopTee:      ".",
+			BottomTee:   "'",
+			LeftTee:     ":",
+			RightTee:    ":",
+			Cross:       "+",
+			Divider:     "-",
 		},
 	}
 )
@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"strings"
 
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/term"
 	"github.com/huandu/xstrings"
@@ -21,22 +22,44 @@ type expandedLine struct {
 	len  int    // line's visible length
 }
 
-// addVertPadding adds vertical padding lines using the given inner width.
+// addVertPadding returns the top and bottom vertical padding lines using the
+// given inner width.
 //
 // innerWidth represents the visible width between the vertical borders.
-func (b *Box) addVertPadding(innerWidth int) ([]string, error) {
+func (b *Box) addVertPadding(innerWidth int, p colorprofile.Profile) ([]string, []string, error) {
+	top, err := b.blankLines(b.padTop, innerWidth, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	bottom, err := b.blankLines(b.padBottom, innerWidth, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return top, bottom, nil
+}
+
+// blankLines returns n empty lines between the vertical borders.
+func (b *Box) blankLines(n, innerWidth int, p colorprofile.Profile) ([]string, error) {
 	if innerWidth < 0 {
 		innerWidth = 0
 	}
-	padding := strings.Repeat(" ", innerWidth)
-	vertical, err := applyColor(b.vertical, b.color)
+	padding, err := applyBackground(strings.Repeat(" ", innerWidth), b.innerBackground(), p)
+	if err != nil {
+		return nil, err
+	}
+	leftWall, rightWall := b.sideWalls()
+	left, err := b.paintChrome(leftWall, p)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.paintChrome(rightWall, p)
 	if err != nil {
 		return nil, err
 	}
 
-	texts := make([]string, b.py)
+	texts := make([]string, n)
 	for i := range texts {
-		texts[i] = vertical + padding + vertical
+		texts[i] = left + padding + right
 	}
 
 	return texts, nil
@@ -61,14 +84,10 @@ func longestLine(lines []string) (int, []expandedLine) {
 				tmpLine.WriteRune(c)
 			}
 		}
-		lineLen = runewidth.StringWidth(tmpLine.String())
+		// Measure without ANSI color codes, which take no space on screen.
+		lineLen = visibleWidth(tmpLine.String())
 		expandedLines = append(expandedLines, expandedLine{tmpLine.String(), lineLen})
 
-		// Check if each line has ANSI Color Code then decrease the length accordingly
-		if runewidth.StringWidth(ansi.Strip(tmpLine.String())) < runewidth.StringWidth(tmpLine.String()) {
-			lineLen = runewidth.StringWidth(ansi.Strip(tmpLine.String()))
-		}
-
 		if lineLen > longest {
 			longest = lineLen
 		}
@@ -109,44 +128,157 @@ func buildPlainBar(left, fill, right string, leftW, rightW, lineWidth, horizonta
 	return left + bar + right
 }
 
-// buildTitledBar builds a top or bottom bar containing a title. It left-aligns
-// the title segment and fills the remaining space on the right with the
-// horizontal glyph. Any leftover width that is not divisible by the glyph's
-// width is emitted as spaces before the emoji sequence so that the last
-// character before the corner is the glyph, not a space.
-func buildTitledBar(left, fill, right string, leftW, rightW, lineWidth, horizontalWidth int, title string) string {
-	if title == "" {
-		return buildPlainBar(left, fill, right, leftW, rightW, lineWidth, horizontalWidth)
+// labelSlots lists the label slots of a border in layout order.
+var labelSlots = [3]AlignType{Left, Center, Right}
+
+// slotIndex returns the index of slot within labelSlots.
+func slotIndex(slot AlignType) (int, bool) {
+	switch slot {
+	case Left, "":
+		return 0, true
+	case Center:
+		return 1, true
+	case Right:
+		return 2, true
+	default:
+		return 0, false
 	}
+}
 
-	plainTitle := title
-	if strings.Contains(plainTitle, "\t") {
-		plainTitle = xstrings.ExpandTabs(plainTitle, 4)
+// labelSegments returns the tab-expanded label segments, each padded with one
+// space on both sides, and their visible widths. Empty labels yield empty
+// segments of width 0.
+func labelSegments(labels [3]string) ([3]string, [3]int) {
+	var segs [3]string
+	var widths [3]int
+	for i, label := range labels {
+		if label == "" {
+			continue
+		}
+		if strings.Contains(label, "\t") {
+			label = xstrings.ExpandTabs(label, 4)
+		}
+		segs[i] = " " + label + " "
+		widths[i] = runewidth.StringWidth(ansi.Strip(label)) + 2
 	}
-	titleWidth := runewidth.StringWidth(ansi.Strip(plainTitle))
-	titleSegWidth := titleWidth + 2 // one space padding on each side
+	return segs, widths
+}
 
-	inner := max(lineWidth-leftW-rightW, titleSegWidth)
-	remaining := inner - titleSegWidth
+// labelsMinWidth returns the minimum inner bar width that fits the labels
+// with at least one cell of fill between adjacent labels.
+func labelsMinWidth(labels [3]string) int {
+	_, widths := labelSegments(labels)
+	total, count := 0, 0
+	for _, w := range widths {
+		if w > 0 {
+			total += w
+			count++
+		}
+	}
+	return total + max(count-1, 0)
+}
 
-	gapWidth := 0
-	fillWidth := remaining
-	if horizontalWidth > 1 {
-		gapWidth = remaining % horizontalWidth
-		fillWidth = remaining - gapWidth
+// layoutLabels returns the offset of every label segment within an inner bar
+// of the given width. The left label starts at the left corner, the right
+// label ends at the right corner and the center label is centered, shifted
+// as needed to keep one cell of fill next to its neighbors.
+func layoutLabels(inner int, widths [3]int) ([3]int, error) {
+	var starts [3]int
+	need, count := 0, 0
+	for _, w := range widths {
+		if w > 0 {
+			need += w
+			count++
+		}
 	}
-	leftSeg := ""
-	rightSeg := buildSegment(fill, fillWidth, horizontalWidth)
-	gap := ""
-	if gapWidth > 0 {
-		gap = strings.Repeat(" ", gapWidth)
+	need += max(count-1, 0)
+	if need > inner {
+		return starts, configError("Width", inner, ErrContentOverflow, "border labels need %d cells but only %d are available", need, inner)
 	}
 
-	return left + leftSeg + " " + plainTitle + " " + gap + rightSeg + right
+	starts[2] = inner - widths[2]
+	lo, hi := 0, inner-widths[1]
+	if widths[0] > 0 {
+		lo = widths[0] + 1
+	}
+	if widths[2] > 0 {
+		hi = starts[2] - 1 - widths[1]
+	}
+	starts[1] = min(max((inner-widths[1])/2, lo), hi)
+	return starts, nil
+}
+
+// buildTitledBar builds a top or bottom bar containing up to three labels in
+// the Left, Center and Right slots, e.g. ┌ Files ──── 12/40 ─── [main] ┐.
+// The space around the labels is filled with the horizontal glyph. Any
+// leftover width that is not divisible by the glyph's width is emitted as
+// spaces next to a label so that the characters beside the corners are
+// glyphs, not spaces.
+//
+// It returns an error if the labels do not fit in lineWidth.
+func buildTitledBar(left, fill, right string, leftW, rightW, lineWidth, horizontalWidth int, labels [3]string) (string, error) {
+	if labels == [3]string{} {
+		return buildPlainBar(left, fill, right, leftW, rightW, lineWidth, horizontalWidth), nil
+	}
+
+	segs, widths := labelSegments(labels)
+	inner := max(lineWidth-leftW-rightW, 0)
+	starts, err := layoutLabels(inner, widths)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(left)
+	cursor := 0
+	for i, seg := range segs {
+		if seg == "" {
+			continue
+		}
+		run, gap := titledBarSide(fill, starts[i]-cursor, horizontalWidth)
+		sb.WriteString(run + gap)
+		sb.WriteString(seg)
+		cursor = starts[i] + widths[i]
+	}
+	// The last run ends at the corner, so its gap goes next to the label.
+	run, gap := titledBarSide(fill, inner-cursor, horizontalWidth)
+	sb.WriteString(gap + run)
+	sb.WriteString(right)
+	return sb.String(), nil
+}
+
+// frameLabels replaces the plain spaces framing each label on bar with
+// space, such as a space with a LabelSpaceBackground.
+func frameLabels(bar string, labels [3]string, space string) string {
+	if space == " " {
+		return bar
+	}
+	segs, _ := labelSegments(labels)
+	var sb strings.Builder
+	for _, seg := range segs {
+		idx := strings.Index(bar, seg)
+		if seg == "" || idx == -1 {
+			continue
+		}
+		sb.WriteString(bar[:idx] + space + seg[1:len(seg)-1] + space)
+		bar = bar[idx+len(seg):]
+	}
+	sb.WriteString(bar)
+	return sb.String()
+}
+
+// titledBarSide splits width into a run of fill glyphs and the spaces left
+// over when width is not a multiple of the glyph width.
+func titledBarSide(fill string, width, horizontalWidth int) (string, string) {
+	gapWidth := 0
+	if horizontalWidth > 1 {
+		gapWidth = width % horizontalWidth
+	}
+	return buildSegment(fill, width-gapWidth, horizontalWidth), strings.Repeat(" ", gapWidth)
 }
 
 // formatLine formats the line according to the information passed.
-func (b *Box) formatLine(lines2 []expandedLine, longestLine, titleLen int, sideMargin, title string, texts []string) ([]string, error) {
+func (b *Box) formatLine(lines2 []expandedLine, longestLine, titleLen int, leftMargin, rightMargin, title string, texts []string, p colorprofile.Profile) ([]string, error) {
 	for i, line := range lines2 {
 		length := line.len
 
@@ -176,12 +308,20 @@ func (b *Box) formatLine(lines2 []expandedLine, longestLine, titleLen int, sideM
 			}
 		}
 
-		spacing := space + sideMargin
 		var format AlignType
+		background := b.innerBackground()
 
 		switch {
-		case i < titleLen && title != "" && b.titlePos == Inside:
+		case i < titleLen && title != "" && b.titlePosition() == Inside:
 			format = centerAlign
+			background = b.titleBackground()
+			if b.titleAlign != "" {
+				align, ok := alignFormat(b.titleAlign)
+				if !ok {
+					return nil, configError("TitleAlign", b.titleAlign, ErrInvalidAlignment, "invalid Title Alignment %s", b.titleAlign)
+				}
+				format = AlignType(align)
+			}
 		default:
 			align, err := b.findAlign()
 			if err != nil {
@@ -190,28 +330,48 @@ func (b *Box) formatLine(lines2 []expandedLine, longestLine, titleLen int, sideM
 			format = AlignType(align)
 		}
 
-		sep, err := applyColor(b.vertical, b.color)
+		leftWall, rightWall := b.sideWalls()
+		leftSep, err := b.paintChrome(leftWall, p)
+		if err != nil {
+			return nil, err
+		}
+		rightSep, err := b.paintChrome(rightWall, p)
 		if err != nil {
 			return nil, err
 		}
 
-		formatted := fmt.Sprintf(string(format), sep, spacing, line.line, oddSpace, space, sideMargin)
-		texts = append(texts, formatted)
+		// The background fills everything between the walls, padding and
+		// alignment spaces included.
+		inner := fmt.Sprintf(string(format), "", leftMargin, line.line, oddSpace, space, rightMargin, "")
+		if inner, err = applyBackground(inner, background, p); err != nil {
+			return nil, err
+		}
+		texts = append(texts, leftSep+inner+rightSep)
 	}
 	return texts, nil
 }
 
 func (b *Box) findAlign() (string, error) {
-	switch b.contentAlign {
+	align, ok := alignFormat(b.contentAlign)
+	if !ok {
+		return "", configError("ContentAlign", b.contentAlign, ErrInvalidAlignment, "invalid Content Alignment %s", b.contentAlign)
+	}
+	return align, nil
+}
+
+// alignFormat returns the line format for the given alignment and whether
+// the alignment is valid.
+func alignFormat(align AlignType) (string, bool) {
+	switch align {
 	case Center:
-		return centerAlign, nil
+		return centerAlign, true
 	case Right:
-		return rightAlign, nil
+		return rightAlign, true
 	case Left, "":
-		// If ContentAlign isn't provided then by default Alignment is Left
-		return leftAlign, nil
+		// If no alignment is provided then by default Alignment is Left
+		return leftAlign, true
 	default:
-		return "", fmt.Errorf("invalid Content Alignment %s", b.contentAlign)
+		return "", false
 	}
 }
 
@@ -222,32 +382,40 @@ func repeatWithString(c string, n int, str string) string {
 	return " " + str + " " + bar
 }
 
-func getConvertedColor(colorStr string) (color.Color, error) {
+// getConvertedColor parses colorStr and degrades it to the given profile.
+// A nil color is returned for profiles without color support.
+func getConvertedColor(colorStr string, p colorprofile.Profile) (color.Color, error) {
 	cv, err := parseColorString(colorStr)
 	if err != nil {
 		return nil, err
 	}
-	// If profile conversion results in nil, fall back to the
-	// parsed color so we always emit color.
-	converted := profile.Convert(cv)
-	if converted == nil {
-		return cv, nil
-	}
-	return converted, nil
+	return p.Convert(cv), nil
 }
 
-func applyColor(str string, colorStr string) (string, error) {
+func applyColor(str string, colorStr string, p colorprofile.Profile) (string, error) {
 	// Empty color string means: do not apply any styling.
 	if colorStr == "" {
 		return str, nil
 	}
-	convertedColor, err := getConvertedColor(colorStr)
+	convertedColor, err := getConvertedColor(colorStr, p)
 	if err != nil {
 		return str, err
 	}
 	return applyConvertedColor(str, convertedColor), nil
 }
 
+// applyBackground is like applyColor but sets the background color.
+func applyBackground(str string, colorStr string, p colorprofile.Profile) (string, error) {
+	if colorStr == "" {
+		return str, nil
+	}
+	convertedColor, err := getConvertedColor(colorStr, p)
+	if err != nil {
+		return str, err
+	}
+	return applyConvertedBackground(str, convertedColor), nil
+}
+
 func stringColorToHex(color string) string {
 	if hex, exists := colorToHex[color]; exists {
 		return hex
@@ -257,27 +425,45 @@ func stringColorToHex(color string) string {
 }
 
 // addStylePreservingOriginalFormat allows to add style around the original formating
+//
+// The style is applied again after every reset (both ESC[0m and the short
+// ESC[m) so that it is not dropped mid-line by styling embedded in s.
 func addStylePreservingOriginalFormat(s string, f func(a string) string) string {
-	const reset = "\033[0m"
-	if !strings.Contains(s, reset) {
+	idx, n := nextReset(s)
+	if idx == -1 {
 		return f(s)
 	}
 
 	var sb strings.Builder
-	start := 0
-	for {
-		idx := strings.Index(s[start:], reset)
-		if idx == -1 {
-			sb.WriteString(f(s[start:]))
-			break
+	for idx != -1 {
+		if idx > 0 {
+			sb.WriteString(f(s[:idx]))
 		}
-		sb.WriteString(f(s[start : start+idx]))
 		// skip the reset sequence (preserve original behavior of removing it)
-		start += idx + len(reset)
+		s = s[idx+n:]
+		idx, n = nextReset(s)
+	}
+	if s != "" {
+		sb.WriteString(f(s))
 	}
 	return sb.String()
 }
 
+// nextReset returns the index and length of the first SGR reset sequence in
+// s, or -1 when there is none.
+func nextReset(s string) (int, int) {
+	const reset, shortReset = "\033[0m", "\033[m"
+	i, j := strings.Index(s, reset), strings.Index(s, shortReset)
+	switch {
+	case i == -1 && j == -1:
+		return -1, 0
+	case j == -1 || (i != -1 && i < j):
+		return i, len(reset)
+	default:
+		return j, len(shortReset)
+	}
+}
+
 // parseColorString converts a color string to color.Color using stringColorToHex and ansi.XParseColor
 func parseColorString(colorStr string) (color.Color, error) {
 	hexColor := stringColorToHex(colorStr)
@@ -288,17 +474,67 @@ func parseColorString(colorStr string) (color.Color, error) {
 
 	colorValue := ansi.XParseColor(hexColor)
 	if colorValue == nil {
-		return nil, fmt.Errorf("unable to parse color: %s", colorStr)
+		return nil, configError("Color", colorStr, ErrInvalidColor, "unable to parse color: %s", colorStr)
 	}
 	return colorValue, nil
 }
 
+// validateColor checks that the color set with the named option can be
+// parsed. An empty color means no styling and is always valid.
+func validateColor(field, colorStr string) error {
+	if colorStr == "" {
+		return nil
+	}
+	if _, err := parseColorString(colorStr); err != nil {
+		return configError(field, colorStr, ErrInvalidColor, "unable to parse color: %s", colorStr)
+	}
+	return nil
+}
+
 func applyConvertedColor(str string, c color.Color) string {
-	if c == nil {
+	if c == nil || str == "" {
 		return str
 	}
+	return applyStyle(str, ansi.Style{}.ForegroundColor(c))
+}
 
-	style := ansi.Style{}.ForegroundColor(c)
+// applyConvertedBackground sets the background of str to c, line by line.
+func applyConvertedBackground(str string, c color.Color) string {
+	if c == nil || str == "" {
+		return str
+	}
+	return applyStyle(str, ansi.Style{}.BackgroundColor(c))
+}
+
+// applyAttrs applies the text attributes attrs to str. Profiles without
+// styling support (ASCII and below) leave str unchanged.
+func applyAttrs(str string, attrs TextAttr, p colorprofile.Profile) string {
+	if attrs == 0 || str == "" || p <= colorprofile.ASCII {
+		return str
+	}
+	var style ansi.Style
+	for _, a := range []struct {
+		attr  TextAttr
+		apply func(ansi.Style) ansi.Style
+	}{
+		{AttrBold, ansi.Style.Bold},
+		{AttrDim, ansi.Style.Faint},
+		{AttrItalic, func(s ansi.Style) ansi.Style { return s.Italic(true) }},
+		{AttrUnderline, func(s ansi.Style) ansi.Style { return s.Underline(true) }},
+		{AttrBlink, func(s ansi.Style) ansi.Style { return s.Blink(true) }},
+		{AttrReverse, func(s ansi.Style) ansi.Style { return s.Reverse(true) }},
+		{AttrStrikethrough, func(s ansi.Style) ansi.Style { return s.Strikethrough(true) }},
+	} {
+		if attrs&a.attr != 0 {
+			style = a.apply(style)
+		}
+	}
+	return applyStyle(str, style)
+}
+
+// applyStyle applies style to every line of str, restoring it after any
+// reset embedded in str.
+func applyStyle(str string, style ansi.Style) string {
 	styled := style.Styled
 
 	// Fast path: no newlines
@@ -321,49 +557,61 @@ func applyConvertedColor(str string, c color.Color) string {
 	return sb.String()
 }
 
-func (b *Box) applyColorBar(topBar, bottomBar, title string) (string, string, error) {
-	if b.titleColor == "" || title == "" {
-		return topBar, bottomBar, nil
-	}
-
+// applyColorBar restores the border color around colored labels (such as
+// the title) on the top and bottom bars, since a label's reset sequence would
+// otherwise clear it for the rest of the bar.
+func (b *Box) applyColorBar(topBar, bottomBar string, topLabels, bottomLabels [3]string, p colorprofile.Profile) (string, string, error) {
 	if strings.TrimSpace(b.color) == "" {
 		return topBar, bottomBar, nil
 	}
 
-	converted, err := getConvertedColor(b.color)
+	converted, err := getConvertedColor(b.color, p)
 	if err != nil {
 		return "", "", err
 	}
+	space, err := b.labelSpace(p)
+	if err != nil {
+		return "", "", err
+	}
+	return recolorLabeledBar(topBar, topLabels, converted, space), recolorLabeledBar(bottomBar, bottomLabels, converted, space), nil
+}
 
-	if b.titlePos == Top {
-		strippedBar := ansi.Strip(topBar)
-		strippedTitle := ansi.Strip(title)
-		if idx := strings.Index(strippedBar, strippedTitle); idx != -1 {
-			// split around first occurrence to preserve any other repeats
-			b0 := applyConvertedColor(strippedBar[:idx], converted)
-			b1 := applyConvertedColor(strippedBar[idx+len(strippedTitle):], converted)
-			coloredTitle, err := applyColor(title, b.titleColor)
-			if err != nil {
-				return "", "", err
-			}
-			topBar = b0 + coloredTitle + b1
+// recolorLabeledBar colors bar with the border color c, keeping the labels'
+// own styling and the space framing them. Bars whose labels carry no styling
+// are returned unchanged.
+func recolorLabeledBar(bar string, labels [3]string, c color.Color, space string) string {
+	styled := false
+	for i, label := range labels {
+		if label != "" && space != " " {
+			labels[i] = space + label + space
 		}
+		if ansi.Strip(labels[i]) != labels[i] {
+			styled = true
+		}
+	}
+	if !styled {
+		return bar
 	}
 
-	if b.titlePos == Bottom {
-		strippedBar := ansi.Strip(bottomBar)
-		strippedTitle := ansi.Strip(title)
-		if idx := strings.Index(strippedBar, strippedTitle); idx != -1 {
-			// split around first occurrence to preserve any other repeats
-			b0 := applyConvertedColor(strippedBar[:idx], converted)
-			b1 := applyConvertedColor(strippedBar[idx+len(strippedTitle):], converted)
-			coloredTitle, err := applyColor(title, b.titleColor)
-			if err != nil {
-				return "", "", err
-			}
-			bottomBar = b0 + coloredTitle + b1
+	var sb strings.Builder
+	strippedBar := ansi.Strip(bar)
+	for _, label := range labels {
+		if label == "" {
+			continue
 		}
+		if strings.Contains(label, "\t") {
+			label = xstrings.ExpandTabs(label, 4)
+		}
+		strippedLabel := ansi.Strip(label)
+		idx := strings.Index(strippedBar, strippedLabel)
+		if idx == -1 {
+			continue
+		}
+		// split around the first occurrence to preserve any other repeats
+		sb.WriteString(applyConvertedColor(strippedBar[:idx], c))
+		sb.WriteString(label)
+		strippedBar = strippedBar[idx+len(strippedLabel):]
 	}
-
-	return topBar, bottomBar, nil
+	sb.WriteString(applyConvertedColor(strippedBar, c))
+	return sb.String()
 }
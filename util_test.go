@@ -5,25 +5,27 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 )
 
 func TestAddVertPadding(t *testing.T) {
 	b := &Box{vertical: "|"}
-	b.py = 2
+	b.padTop = 2
+	b.padBottom = 1
 
 	// innerWidth is the visible width between the vertical borders.
-	got, err := b.addVertPadding(4)
+	top, bottom, err := b.addVertPadding(4, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("addVertPadding unexpected error: %v", err)
 	}
-	if len(got) != 2 {
-		t.Fatalf("expected 2 padding lines, got %d", len(got))
+	if len(top) != 2 || len(bottom) != 1 {
+		t.Fatalf("expected 2 top and 1 bottom padding lines, got %d and %d", len(top), len(bottom))
 	}
 
 	want := "|    |" // len-2 = 4 spaces
-	for i, line := range got {
+	for i, line := range append(top, bottom...) {
 		if line != want {
 			t.Errorf("line %d: expected %q, got %q", i, want, line)
 		}
@@ -71,7 +73,7 @@ func TestFormatLine_LeftAlignAndError(t *testing.T) {
 
 	lines := []expandedLine{{line: "hi", len: 2}}
 	sideMargin := " "
-	texts, err := b.formatLine(lines, 2, 0, sideMargin, "", nil)
+	texts, err := b.formatLine(lines, 2, 0, sideMargin, sideMargin, "", nil, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("formatLine unexpected error: %v", err)
 	}
@@ -80,8 +82,8 @@ func TestFormatLine_LeftAlignAndError(t *testing.T) {
 	}
 
 	// With left alignment and no additional padding the layout is:
-	// sep + sideMargin + line + spacing + sideMargin + sep
-	// where spacing == sideMargin and sep == "|".
+	// sep + leftMargin + line + rightMargin + sep
+	// where both margins are sideMargin and sep == "|".
 	want := "| hi |"
 	if texts[0] != want {
 		t.Errorf("formatted line mismatch: want %q, got %q", want, texts[0])
@@ -92,7 +94,7 @@ func TestFormatLine_LeftAlignAndError(t *testing.T) {
 	b.titlePos = Inside
 	b.contentAlign = AlignType("InvalidAlign")
 	lines = []expandedLine{{line: "Title", len: len("Title")}}
-	texts, err = b.formatLine(lines, len("Title"), 1, sideMargin, "Title", nil)
+	texts, err = b.formatLine(lines, len("Title"), 1, sideMargin, sideMargin, "Title", nil, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("formatLine for title line should not error, got: %v", err)
 	}
@@ -104,7 +106,7 @@ func TestFormatLine_LeftAlignAndError(t *testing.T) {
 	b = &Box{vertical: "|"}
 	b.contentAlign = AlignType("InvalidAlign")
 	lines = []expandedLine{{line: "hi", len: 2}}
-	_, err = b.formatLine(lines, 2, 0, sideMargin, "", nil)
+	_, err = b.formatLine(lines, 2, 0, sideMargin, sideMargin, "", nil, colorprofile.TrueColor)
 	if err == nil {
 		t.Fatalf("expected error for invalid content alignment, got nil")
 	}
@@ -154,7 +156,7 @@ func TestRepeatWithString(t *testing.T) {
 }
 
 func TestGetConvertedColorAndApplyColor(t *testing.T) {
-	c, err := getConvertedColor(Green)
+	c, err := getConvertedColor(Green, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("expected non-error from getConvertedColor: %v", err)
 	}
@@ -163,7 +165,7 @@ func TestGetConvertedColorAndApplyColor(t *testing.T) {
 	}
 
 	text := "hello"
-	got, err := applyColor(text, "")
+	got, err := applyColor(text, "", colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("expected no error when color is empty: %v", err)
 	}
@@ -171,7 +173,7 @@ func TestGetConvertedColorAndApplyColor(t *testing.T) {
 		t.Errorf("expected text unchanged when color is empty, got %q", got)
 	}
 
-	colored, err := applyColor(text, Green)
+	colored, err := applyColor(text, Green, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("unexpected error applying valid color: %v", err)
 	}
@@ -179,7 +181,7 @@ func TestGetConvertedColorAndApplyColor(t *testing.T) {
 		t.Errorf("expected stripped colored text to equal %q, got %q", text, ansi.Strip(colored))
 	}
 
-	if _, err := applyColor(text, "NotAColor"); err == nil {
+	if _, err := applyColor(text, "NotAColor", colorprofile.TrueColor); err == nil {
 		t.Fatalf("expected error when applying unknown color name")
 	}
 }
@@ -275,14 +277,20 @@ func TestApplyColorBar(t *testing.T) {
 	bottom := "+-----------------+"
 	title := "TITLE"
 
-	// Early return when titleColor is empty or title is empty.
+	// Early return when the labels are not colored.
 	b := &Box{}
-	gotTop, gotBottom, err := b.applyColorBar(top, bottom, title)
+	b.color = BrightBlue
+	gotTop, gotBottom, err := b.applyColorBar(top, bottom, [3]string{title}, [3]string{}, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("applyColorBar unexpected error: %v", err)
 	}
 	if gotTop != top || gotBottom != bottom {
-		t.Errorf("expected bars unchanged when titleColor is empty")
+		t.Errorf("expected bars unchanged when labels are not colored")
+	}
+
+	coloredTitle, err := applyColor(title, BrightRed, colorprofile.TrueColor)
+	if err != nil {
+		t.Fatalf("unexpected error coloring title: %v", err)
 	}
 
 	// Title at top: top bar should be recolored but visually unchanged when stripped.
@@ -290,7 +298,7 @@ func TestApplyColorBar(t *testing.T) {
 	b.titleColor = BrightRed
 	b.color = BrightBlue
 	b.titlePos = Top
-	gotTop, gotBottom, err = b.applyColorBar(top, bottom, title)
+	gotTop, gotBottom, err = b.applyColorBar(top, bottom, [3]string{coloredTitle}, [3]string{}, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("applyColorBar unexpected error for top title: %v", err)
 	}
@@ -311,7 +319,7 @@ func TestApplyColorBar(t *testing.T) {
 	b.titleColor = BrightRed
 	b.color = BrightBlue
 	b.titlePos = Bottom
-	gotTop, gotBottom, err = b.applyColorBar(topPlain, bottomWithTitle, title)
+	gotTop, gotBottom, err = b.applyColorBar(topPlain, bottomWithTitle, [3]string{}, [3]string{coloredTitle}, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("applyColorBar unexpected error for bottom title: %v", err)
 	}
@@ -326,16 +334,12 @@ func TestApplyColorBar(t *testing.T) {
 	}
 
 	// No box color set: bars should remain unchanged so existing styling is preserved.
-	coloredTitle, err := applyColor(title, BrightRed)
-	if err != nil {
-		t.Fatalf("unexpected error coloring title: %v", err)
-	}
 	topWithColoredTitle := strings.Replace(top, title, coloredTitle, 1)
 	b = &Box{}
 	b.titleColor = BrightRed
 	b.color = ""
 	b.titlePos = Top
-	gotTop, gotBottom, err = b.applyColorBar(topWithColoredTitle, bottom, coloredTitle)
+	gotTop, gotBottom, err = b.applyColorBar(topWithColoredTitle, bottom, [3]string{coloredTitle}, [3]string{}, colorprofile.TrueColor)
 	if err != nil {
 		t.Fatalf("applyColorBar unexpected error when Color is empty: %v", err)
 	}
@@ -404,7 +408,10 @@ func TestBuildTitledBar_LeftAlignedWithEmojiFill(t *testing.T) {
 	rightW := hw
 	lineWidth := hw*20 + leftW + rightW
 
-	bar := buildTitledBar(left, fill, right, leftW, rightW, lineWidth, hw, title)
+	bar, err := buildTitledBar(left, fill, right, leftW, rightW, lineWidth, hw, [3]string{title})
+	if err != nil {
+		t.Fatalf("buildTitledBar unexpected error: %v", err)
+	}
 	if w := runewidth.StringWidth(ansi.Strip(bar)); w != lineWidth {
 		t.Fatalf("expected bar visual width %d, got %d", lineWidth, w)
 	}
@@ -419,3 +426,41 @@ func TestBuildTitledBar_LeftAlignedWithEmojiFill(t *testing.T) {
 		t.Errorf("expected bar to end with fill glyph, got %q", plain)
 	}
 }
+
+func TestBuildTitledBarLabels(t *testing.T) {
+	bar, err := buildTitledBar("┌", "─", "┐", 1, 1, 33, 1, [3]string{"Files", "12/40", "[main]"})
+	if err != nil {
+		t.Fatalf("buildTitledBar unexpected error: %v", err)
+	}
+	if want := "┌ Files ───── 12/40 ──── [main] ┐"; bar != want {
+		t.Errorf("expected %q, got %q", want, bar)
+	}
+
+	bar, err = buildTitledBar("┌", "─", "┐", 1, 1, 12, 1, [3]string{"", "", "end"})
+	if err != nil {
+		t.Fatalf("buildTitledBar unexpected error: %v", err)
+	}
+	if want := "┌───── end ┐"; bar != want {
+		t.Errorf("expected %q, got %q", want, bar)
+	}
+
+	// Labels that do not fit at a fixed width are an error, not a wider bar.
+	_, err = buildTitledBar("┌", "─", "┐", 1, 1, 12, 1, [3]string{"left", "", "right"})
+	if err == nil || !strings.Contains(err.Error(), "border labels need 14 cells but only 10 are available") {
+		t.Errorf("expected fit error, got %v", err)
+	}
+}
+
+func TestLayoutLabelsKeepsCenterClearOfNeighbors(t *testing.T) {
+	// A wide left label pushes the center label right of its natural spot.
+	starts, err := layoutLabels(20, [3]int{10, 4, 3})
+	if err != nil {
+		t.Fatalf("layoutLabels unexpected error: %v", err)
+	}
+	if starts != [3]int{0, 11, 17} {
+		t.Errorf("unexpected offsets %v", starts)
+	}
+	if got := labelsMinWidth([3]string{"ab", "", "cd"}); got != 9 {
+		t.Errorf("expected min width 9, got %d", got)
+	}
+}